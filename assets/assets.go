@@ -0,0 +1,25 @@
+// Package assets holds the default word lists, built into the binaries so a
+// missing word list file can fall back to them.
+package assets
+
+import _ "embed"
+
+// Words is the default answer list.
+//
+//go:embed words.txt
+var Words []byte
+
+// Allowed is the default list of extra allowed guesses.
+//
+//go:embed allowed.txt
+var Allowed []byte
+
+// SpanishWords is the answer list of the Spanish language pack.
+//
+//go:embed es/words.txt
+var SpanishWords []byte
+
+// GermanWords is the answer list of the German language pack.
+//
+//go:embed de/words.txt
+var GermanWords []byte
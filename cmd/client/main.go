@@ -27,7 +27,7 @@ func main() {
 			fmt.Println("Nickname cannot be empty. Please try again.")
 		}
 	}
-	client, err := client.NewClient(ipAddress, nickname)
+	client, err := client.NewClient(ipAddress, nickname, controller.Features)
 	if err != nil {
 		log.Fatal("Error creating client:", err)
 	} else {
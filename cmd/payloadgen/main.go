@@ -0,0 +1,67 @@
+// Command payloadgen writes the web client's payload types and the protocol's
+// JSON Schema from multiplayer.PayloadRegistry. It is run by go generate in
+// internal/multiplayer.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tomlaws/wordle/internal/game"
+	"github.com/tomlaws/wordle/internal/multiplayer"
+	"github.com/tomlaws/wordle/internal/payloadgen"
+	"github.com/tomlaws/wordle/internal/protocol"
+)
+
+func main() {
+	tsPath := flag.String("ts", "", "write the TypeScript payload types to this file")
+	schemaPath := flag.String("schema", "", "write the JSON Schema to this file")
+	check := flag.Bool("check", false, "report files that are out of date instead of writing them")
+	flag.Parse()
+	generator := payloadgen.New(multiplayer.PayloadRegistry,
+		payloadgen.WithEnum(game.Modes...),
+		payloadgen.WithEnum(game.Feedbacks...),
+		payloadgen.WithEnum(game.Tiers...),
+		payloadgen.WithEnum(protocol.ErrorCodes...),
+		payloadgen.WithNullable("GameOverPayload.Winner"),
+	)
+	if err := generate(generator, *tsPath, *schemaPath, *check); err != nil {
+		fmt.Fprintln(os.Stderr, "payloadgen:", err)
+		os.Exit(1)
+	}
+}
+
+func generate(generator *payloadgen.Generator, tsPath, schemaPath string, check bool) error {
+	if tsPath != "" {
+		if err := write(tsPath, generator.TypeScript(), check); err != nil {
+			return err
+		}
+	}
+	if schemaPath != "" {
+		schema, err := generator.JSONSchema()
+		if err != nil {
+			return err
+		}
+		if err := write(schemaPath, schema, check); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write writes data to path, or in check mode fails if path holds anything else.
+func write(path string, data []byte, check bool) error {
+	if !check {
+		return os.WriteFile(path, data, 0644)
+	}
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(current, data) {
+		return fmt.Errorf("%s is out of date, run go generate ./internal/multiplayer", path)
+	}
+	return nil
+}
@@ -1,10 +1,18 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"math/rand"
 	"net/http"
+	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
+	"time"
 
+	"github.com/tomlaws/wordle/internal/bot"
+	"github.com/tomlaws/wordle/internal/game"
 	"github.com/tomlaws/wordle/internal/multiplayer"
 	"github.com/tomlaws/wordle/internal/server"
 )
@@ -13,6 +21,30 @@ var Port string = "8080"
 var MaxGuesses string = "6"
 var ThinkTime string = "60"
 var WordListPath string = "assets/words.txt"
+var AllowedGuessesPath string = "assets/allowed.txt"
+var BlocklistPath string = ""
+var WordLength string = "5"
+var Mode string = "classic"
+var Boards string = "1"
+var DailySecret string = ""
+var HardMode string = "false"
+var Bots string = "0"
+var Feedback string = "wordle"
+var Tier string = "any"
+var Language string = "en"
+var Seed string = ""
+var MatchSeed string = ""
+var ReloadInterval string = "10"
+var RepeatWindow string = "30"
+var ServerRepeatWindow string = "10"
+var RateLimit string = "20"
+var MaxStrikes string = "10"
+
+// botThinkTime is how long bots wait before submitting a guess.
+const botThinkTime = 2 * time.Second
+
+// maxBoards is the most boards a match can play at once.
+const maxBoards = 8
 
 func main() {
 	var maxGuessesInt int
@@ -30,11 +62,145 @@ func main() {
 	if thinkTimeInt < 1 {
 		log.Fatal("Invalid think time. Must be >= 1.")
 	}
-	lobby := multiplayer.NewLobby(WordListPath, maxGuessesInt, thinkTimeInt)
+	var wordLengthInt int
+	if wl, err := strconv.Atoi(WordLength); err == nil {
+		wordLengthInt = wl
+	}
+	if !game.IsValidLength(wordLengthInt) {
+		log.Fatalf("Invalid word length. Must be between %d and %d.", game.MinWordLength, game.MaxWordLength)
+	}
+	mode, err := game.ParseMode(Mode)
+	if err != nil {
+		log.Fatal("Invalid mode. Must be classic, absurd, daily, fibble or nerdle.")
+	}
+	var boardsInt int
+	if b, err := strconv.Atoi(Boards); err == nil {
+		boardsInt = b
+	}
+	if boardsInt < 1 || boardsInt > maxBoards {
+		log.Fatalf("Invalid boards. Must be between 1 and %d.", maxBoards)
+	}
+	if mode == game.Absurd && boardsInt > 1 {
+		// Every board would dodge the same guesses the same way.
+		log.Fatal("Absurd mode can only be played on one board.")
+	}
+	feedback, err := game.ParseFeedback(Feedback)
+	if err != nil {
+		log.Fatal("Invalid feedback. Must be wordle, mastermind, peaks or lying.")
+	}
+	if mode == game.Absurd && feedback == game.LyingFeedback {
+		log.Fatal("Lying feedback cannot be used in absurd mode.")
+	}
+	hardModeBool, err := strconv.ParseBool(HardMode)
+	if err != nil {
+		log.Fatal("Invalid hard mode. Must be true or false.")
+	}
+	if hardModeBool && (mode == game.Fibble || feedback == game.LyingFeedback) {
+		log.Fatal("Hard mode cannot be used with lying feedback.")
+	}
+	tier, err := game.ParseTier(Tier)
+	if err != nil {
+		log.Fatal("Invalid tier. Must be any, easy, normal or hard.")
+	}
+	if tier != game.AnyTier && mode != game.Classic && mode != game.Fibble {
+		log.Fatal("Tiers can only be used in classic and fibble modes.")
+	}
+	var botsInt int
+	if b, err := strconv.Atoi(Bots); err == nil {
+		botsInt = b
+	}
+	if botsInt > 0 && mode == game.Nerdle {
+		log.Fatal("Bots cannot play nerdle.")
+	}
+	if botsInt > 0 && feedback != game.WordleFeedback && feedback != game.LyingFeedback {
+		log.Fatal("Bots can only play with wordle or lying feedback.")
+	}
+	language, err := game.LookupLanguage(Language)
+	if err != nil {
+		log.Fatalf("Invalid language: %v", err)
+	}
+	wordListPath, allowedGuessesPath := WordListPath, AllowedGuessesPath
+	if wordListPath == game.English.WordListPath {
+		wordListPath = language.WordListPath
+	}
+	if allowedGuessesPath == game.English.AllowedGuessesPath {
+		allowedGuessesPath = language.AllowedGuessesPath
+	}
+	lobbyOptions := []multiplayer.LobbyOption{
+		multiplayer.WithLanguage(language),
+		multiplayer.WithWordLength(wordLengthInt),
+		multiplayer.WithMode(mode),
+		multiplayer.WithBoards(boardsInt),
+		multiplayer.WithDailySecret(DailySecret),
+		multiplayer.WithHardMode(hardModeBool),
+		multiplayer.WithFeedback(feedback),
+		multiplayer.WithTier(tier),
+	}
+	if allowedGuessesPath != "" {
+		lobbyOptions = append(lobbyOptions, multiplayer.WithAllowedGuesses(allowedGuessesPath))
+	}
+	if BlocklistPath != "" {
+		lobbyOptions = append(lobbyOptions, multiplayer.WithBlocklist(BlocklistPath))
+	}
+	reloadIntervalInt, err := strconv.Atoi(ReloadInterval)
+	if err != nil || reloadIntervalInt < 0 {
+		log.Fatal("Invalid reload interval. Must be >= 0.")
+	}
+	lobbyOptions = append(lobbyOptions, multiplayer.WithReloadInterval(time.Duration(reloadIntervalInt)*time.Second))
+	if Seed != "" {
+		seed, err := strconv.ParseInt(Seed, 10, 64)
+		if err != nil {
+			log.Fatal("Invalid seed. Must be an integer.")
+		}
+		lobbyOptions = append(lobbyOptions, multiplayer.WithSource(rand.NewSource(seed)))
+	}
+	if MatchSeed != "" {
+		matchSeed, err := strconv.ParseInt(MatchSeed, 10, 64)
+		if err != nil {
+			log.Fatal("Invalid match seed. Must be an integer.")
+		}
+		log.Printf("Replaying match seed %d in every match", matchSeed)
+		lobbyOptions = append(lobbyOptions, multiplayer.WithMatchSeed(matchSeed))
+	}
+	repeatWindowInt, err := strconv.Atoi(RepeatWindow)
+	if err != nil || repeatWindowInt < 0 {
+		log.Fatal("Invalid repeat window. Must be >= 0.")
+	}
+	serverRepeatWindowInt, err := strconv.Atoi(ServerRepeatWindow)
+	if err != nil || serverRepeatWindowInt < 0 {
+		log.Fatal("Invalid server repeat window. Must be >= 0.")
+	}
+	lobbyOptions = append(lobbyOptions, multiplayer.WithRepeatWindow(repeatWindowInt, serverRepeatWindowInt))
+	rateLimitInt, err := strconv.Atoi(RateLimit)
+	if err != nil || rateLimitInt < 0 {
+		log.Fatal("Invalid rate limit. Must be >= 0.")
+	}
+	lobbyOptions = append(lobbyOptions, multiplayer.WithRateLimit(rateLimitInt, time.Second))
+	maxStrikesInt, err := strconv.Atoi(MaxStrikes)
+	if err != nil || maxStrikesInt < 0 {
+		log.Fatal("Invalid max strikes. Must be >= 0.")
+	}
+	lobbyOptions = append(lobbyOptions, multiplayer.WithMaxStrikes(maxStrikesInt))
+	lobby := multiplayer.NewLobby(wordListPath, maxGuessesInt, thinkTimeInt, lobbyOptions...)
+	for i := 1; i <= botsInt; i++ {
+		lobby.NewPlayer(bot.NewBot(fmt.Sprintf("Bot %d", i), lobby.WordList, botThinkTime))
+	}
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if err := lobby.Reload(); err != nil {
+				log.Printf("Error reloading word list: %v", err)
+			}
+		}
+	}()
 	handler := server.NewServer(
 		func(client *server.Client) {
 			lobby.NewPlayer(client)
 		},
+		server.WithVariants(lobby.Variants()...),
+		server.WithWordLength(lobby.WordLength()),
+		server.WithBoards(lobby.Boards()),
 	)
 	http.HandleFunc("/socket", handler)
 	log.Printf("Server starting on %s", ":"+Port)
@@ -1,70 +1,280 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"math/rand"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/tomlaws/wordle/internal/game"
+	"github.com/tomlaws/wordle/internal/share"
+	"github.com/tomlaws/wordle/internal/solver"
 )
 
 var MaxGuesses string = "6"
 var WordListPath string = "assets/words.txt"
+var AllowedGuessesPath string = "assets/allowed.txt"
+var BlocklistPath string = ""
+var WordLength string = "5"
+var Mode string = "classic"
+var Boards string = "1"
+var DailySecret string = ""
+var HardMode string = "false"
+var StateFile string = ""
+var Seed string = ""
+var Feedback string = "wordle"
+var Tier string = "any"
+var Language string = "en"
 
-func RunGame(input io.Reader, output io.Writer, wordListPath string, maxGuesses int) {
+type settings struct {
+	wordLength      int
+	mode            game.Mode
+	boards          int
+	dailySecret     string
+	hardMode        bool
+	feedback        game.Feedback
+	tier            game.Tier
+	language        *game.Language
+	stateFile       string
+	source          rand.Source
+	wordListOptions []game.WordListOption
+}
+
+// Option configures a standalone game started by RunGame.
+type Option func(*settings)
+
+// WithWordLength sets the number of letters in the answer.
+func WithWordLength(length int) Option {
+	return func(s *settings) {
+		s.wordLength = length
+	}
+}
+
+// WithMode sets the game variant to play.
+func WithMode(mode game.Mode) Option {
+	return func(s *settings) {
+		s.mode = mode
+	}
+}
+
+// WithBoards sets how many boards are played at once with a shared guess
+// budget.
+func WithBoards(boards int) Option {
+	return func(s *settings) {
+		s.boards = boards
+	}
+}
+
+// WithDailySecret sets the secret that keys the daily puzzle shuffle.
+func WithDailySecret(secret string) Option {
+	return func(s *settings) {
+		s.dailySecret = secret
+	}
+}
+
+// WithAllowedGuesses accepts the words in path as guesses in addition to the
+// answers in the word list.
+func WithAllowedGuesses(path string) Option {
+	return func(s *settings) {
+		s.wordListOptions = append(s.wordListOptions, game.WithAllowedGuesses(path))
+	}
+}
+
+// WithBlocklist never picks the words in path as answers. They are still
+// accepted as guesses.
+func WithBlocklist(path string) Option {
+	return func(s *settings) {
+		s.wordListOptions = append(s.wordListOptions, game.WithBlocklist(path))
+	}
+}
+
+// WithSource draws random answers from source, so a seeded source replays
+// the same sequence of games.
+func WithSource(source rand.Source) Option {
+	return func(s *settings) {
+		s.source = source
+		s.wordListOptions = append(s.wordListOptions, game.WithSource(source))
+	}
+}
+
+// WithHardMode requires every guess to reuse the hints revealed so far.
+func WithHardMode(enabled bool) Option {
+	return func(s *settings) {
+		s.hardMode = enabled
+	}
+}
+
+// WithFeedback sets how guesses are scored.
+func WithFeedback(feedback game.Feedback) Option {
+	return func(s *settings) {
+		s.feedback = feedback
+	}
+}
+
+// WithTier picks classic and fibble answers from the given difficulty tier.
+func WithTier(tier game.Tier) Option {
+	return func(s *settings) {
+		s.tier = tier
+	}
+}
+
+// WithLanguage plays with the given language pack's alphabet and keyboard.
+func WithLanguage(language *game.Language) Option {
+	return func(s *settings) {
+		s.language = language
+		s.wordListOptions = append(s.wordListOptions, game.WithLanguage(language))
+	}
+}
+
+// WithStateFile autosaves unfinished games to path and offers to resume them
+// on the next start. Only classic games are saved.
+func WithStateFile(path string) Option {
+	return func(s *settings) {
+		s.stateFile = path
+	}
+}
+
+func RunGame(input io.Reader, output io.Writer, wordListPath string, maxGuesses int, opts ...Option) {
+	s := settings{wordLength: game.DefaultWordLength, mode: game.Classic, boards: 1, feedback: game.WordleFeedback, tier: game.AnyTier, language: game.English}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	if s.mode != game.Classic && s.mode != game.Fibble {
+		s.tier = game.AnyTier
+	}
+	if s.mode == game.Fibble {
+		s.feedback = game.LyingFeedback
+	}
+	if s.mode == game.Nerdle {
+		s.wordLength = game.EquationLength
+	}
+	// Hard mode rules cannot be enforced against feedback that lies.
+	if s.feedback == game.LyingFeedback {
+		s.hardMode = false
+	}
 	fmt.Fprintln(output, "Welcome to Wordle!")
 	for {
-		fmt.Fprintf(output, "Guess the 5-letter word in %d rounds.\n", maxGuesses)
-		wordlist, err := game.NewWordList(wordListPath)
+		answers, err := loadAnswers(output, wordListPath, s)
 		if err != nil {
 			fmt.Fprintf(output, "Error loading word list: %v\n", err)
 			return
 		}
-		answer := wordlist.RandomWord()
-		//println("Debug: The answer is", answer) // For testing purposes
-
-		if answer == "" {
-			fmt.Fprintln(output, "Word list is empty. Cannot start the game.")
-			return
+		g := resumeGame(input, output, answers, s)
+		if g == nil {
+			if s.mode == game.Nerdle {
+				fmt.Fprintf(output, "Guess the %d-character equation, such as 12+35=47, in %d rounds.\n", s.wordLength, maxGuesses)
+			} else if s.boards > 1 {
+				fmt.Fprintf(output, "Guess all %d of the %d-letter words in %d rounds.\n", s.boards, s.wordLength, maxGuesses)
+			} else {
+				fmt.Fprintf(output, "Guess the %d-letter word in %d rounds.\n", s.wordLength, maxGuesses)
+			}
+			if s.mode == game.Daily {
+				fmt.Fprintf(output, "Daily #%d\n", game.DailyNumber(time.Now()))
+			}
+			if s.mode == game.Absurd {
+				fmt.Fprintln(output, "Absurd mode is on: the answer keeps changing to dodge your guesses.")
+			}
+			switch s.feedback {
+			case game.MastermindFeedback:
+				fmt.Fprintln(output, "Mastermind feedback: only the number of [hits] and (present) letters is shown.")
+			case game.PeaksFeedback:
+				fmt.Fprintln(output, "Peaks feedback: arrows show whether the letter in each position comes later or earlier in the alphabet.")
+			case game.LyingFeedback:
+				fmt.Fprintln(output, "Lying feedback: one tile in every row is false.")
+			}
+			if s.tier != game.AnyTier {
+				fmt.Fprintf(output, "Difficulty: %s\n", s.tier)
+			}
+			if s.hardMode {
+				fmt.Fprintln(output, "Hard mode is on: revealed hints must be used in every guess.")
+			}
+			if g, err = newGame(answers, s, maxGuesses); err != nil {
+				fmt.Fprintf(output, "Cannot start the game: %v.\n", err)
+				return
+			}
+		}
+		hints := make([]*solver.Solver, len(g.Boards))
+		for i := range hints {
+			hints[i] = solver.New(answers, s.wordLength,
+				solver.WithHardMode(s.hardMode),
+				solver.WithLies(s.feedback == game.LyingFeedback),
+			)
 		}
-		g := game.NewGame(answer, maxGuesses)
-		for g.State == game.InProgress {
+		// The equation pool is too large to rank guesses interactively.
+		hintsEnabled := s.mode != game.Nerdle && (s.feedback == game.WordleFeedback || s.feedback == game.LyingFeedback)
+		if hintsEnabled {
+			fmt.Fprintln(output, "Type \"hint\" for suggestions.")
+		}
+		for g.Status() == game.InProgress {
 			var guess string
-			fmt.Fprintf(output, "Enter your guess (%d/%d): ", len(g.Attempts)+1, maxGuesses)
+			fmt.Fprintf(output, "Enter your guess (%d/%d): ", g.Guesses()+1, maxGuesses)
 			fmt.Fscanln(input, &guess)
-			if len(guess) != 5 {
-				fmt.Fprintln(output, "Please enter a 5-letter word.")
+			if hintsEnabled && strings.EqualFold(guess, "hint") {
+				printHints(output, hints, g)
+				continue
+			}
+			if s.mode != game.Nerdle {
+				guess = s.language.Normalize(guess)
+			}
+			if game.Length(guess) != s.wordLength {
+				if s.mode == game.Nerdle {
+					fmt.Fprintf(output, "Please enter an equation of %d characters.\n", s.wordLength)
+				} else {
+					fmt.Fprintf(output, "Please enter a %d-letter word.\n", s.wordLength)
+				}
 				continue
 			}
-			if wordlist.IsValidWord(guess) == false {
-				fmt.Fprintln(output, "Not a valid word. Please try again.")
+			if err := answers.CheckGuess(guess); err != nil {
+				if s.mode == game.Nerdle {
+					fmt.Fprintf(output, "Not a valid equation (%v). Please try again.\n", err)
+				} else {
+					fmt.Fprintln(output, "Not a valid word. Please try again.")
+				}
 				continue
 			}
 
-			result, err := g.MakeGuess(guess)
+			results, err := g.MakeGuess(guess)
 			if err != nil {
 				fmt.Fprintf(output, "Error: %v\n", err)
 				continue
 			}
-			for _, lr := range result {
-				switch lr.MatchType {
-				case game.Hit:
-					fmt.Fprintf(output, "[%c] ", lr.Letter)
-				case game.Present:
-					fmt.Fprintf(output, "(%c) ", lr.Letter)
-				case game.Miss:
-					fmt.Fprintf(output, " %c  ", lr.Letter)
-				}
+			if err := saveGame(s, g); err != nil {
+				fmt.Fprintf(output, "Could not save game: %v\n", err)
 			}
-			if g.State == game.InProgress {
+			printResults(output, guess, results, s.wordLength)
+			if g.Status() == game.InProgress {
 				fmt.Fprintln(output)
+				printKeyboards(output, g.Keyboards(), layout(s))
 			}
 		}
-		if g.State == game.Won {
+		if g.Status() == game.Won {
 			fmt.Fprintln(output, "\nCongratulations! You've guessed the word!")
-		} else if g.State == game.Lost {
-			fmt.Fprintf(output, "\nGame over! The correct word was: %s\n", g.Answer)
+		} else if g.Status() == game.Lost {
+			fmt.Fprintf(output, "\nGame over! The correct word was: %s\n", strings.Join(g.Solutions(), ", "))
+		}
+		for _, lie := range g.Lies() {
+			if len(g.Boards) > 1 {
+				fmt.Fprintf(output, "Board %d, ", lie.Board+1)
+			}
+			fmt.Fprintf(output, "Guess %d: %v\n", lie.Attempt+1, lie)
+		}
+		grid := share.FromMultiGame(g, maxGuesses, s.hardMode)
+		if s.mode == game.Daily {
+			grid.Puzzle = game.DailyNumber(time.Now())
+		}
+		if s.mode == game.Nerdle {
+			grid.Title = "Nerdle"
+		}
+		fmt.Fprintf(output, "\n%s\n\n", grid)
+		if s.mode == game.Daily {
+			fmt.Fprintf(output, "Come back tomorrow for Daily #%d!\n", game.DailyNumber(time.Now())+1)
+			break
 		}
 		// ask to play again
 		var playAgain string
@@ -78,10 +288,315 @@ func RunGame(input io.Reader, output io.Writer, wordListPath string, maxGuesses
 
 }
 
+// loadAnswers loads the word list, or generates the equations in Nerdle mode.
+// The list is loaded again for every game, so edits apply to the next game.
+// A missing list falls back to the built-in one.
+func loadAnswers(output io.Writer, wordListPath string, s settings) (game.AnswerProvider, error) {
+	if s.mode == game.Nerdle {
+		return game.NewEquationPool(s.wordLength, s.wordListOptions...)
+	}
+	wordList, err := game.NewWordList(wordListPath, s.wordListOptions...)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(output, "Word list not found, using the built-in list: %v\n", err)
+		return game.DefaultWordList(s.wordListOptions...)
+	}
+	return wordList, err
+}
+
+// layout returns the keys shown on the keyboard.
+func layout(s settings) []string {
+	if s.mode == game.Nerdle {
+		return game.EquationLayout
+	}
+	return s.language.Layout
+}
+
+// newGame creates the boards for a new game, or returns an error if there are
+// not enough answers.
+func newGame(answers game.AnswerProvider, s settings, maxGuesses int) (*game.MultiGame, error) {
+	boards := make([]game.Playable, s.boards)
+	source := s.source
+	if source == nil {
+		source = rand.NewSource(time.Now().UnixNano())
+	}
+	opts := func() []game.Option {
+		return []game.Option{
+			game.WithHardMode(s.hardMode),
+			game.WithEvaluator(s.feedback.Evaluator(source)),
+		}
+	}
+	switch s.mode {
+	case game.Absurd:
+		candidates := answers.Answers(s.wordLength)
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("word list has no %d-letter words", s.wordLength)
+		}
+		for i := range boards {
+			boards[i] = game.NewAbsurdGame(candidates, maxGuesses, opts()...)
+		}
+	case game.Daily:
+		// Daily mode always plays words.
+		wordlist := answers.(*game.WordList)
+		words, err := wordlist.DailyWords(time.Now(), s.dailySecret, s.wordLength, s.boards)
+		if err != nil {
+			return nil, fmt.Errorf("daily puzzle unavailable: %v", err)
+		}
+		for i, answer := range words {
+			boards[i] = game.NewGame(answer, maxGuesses, opts()...)
+		}
+	default:
+		var words []string
+		if wordList, ok := answers.(*game.WordList); ok && s.tier != game.AnyTier {
+			words = wordList.RandomWordsFrom(rand.New(source), s.wordLength, s.boards, func(answer string) bool {
+				return !wordList.InTier(answer, s.tier)
+			})
+		} else {
+			words = answers.RandomWordsOfLength(s.wordLength, s.boards)
+		}
+		if len(words) < s.boards {
+			return nil, fmt.Errorf("word list has too few %d-letter words", s.wordLength)
+		}
+		for i, answer := range words {
+			boards[i] = game.NewGame(answer, maxGuesses, opts()...)
+		}
+	}
+	return game.NewMultiGame(boards...), nil
+}
+
+// resumeGame offers to resume the game saved in the state file. It returns nil
+// if there is no saved game or the player declines.
+func resumeGame(input io.Reader, output io.Writer, answers game.AnswerProvider, s settings) *game.MultiGame {
+	if s.stateFile == "" {
+		return nil
+	}
+	file, err := os.Open(s.stateFile)
+	if err != nil {
+		return nil
+	}
+	saved, err := game.ReadSavedGames(file)
+	file.Close()
+	if err != nil || !resumable(saved, s) {
+		return nil
+	}
+	var resume string
+	fmt.Fprint(output, "Resume your unfinished game? (y/n): ")
+	fmt.Fscanln(input, &resume)
+	if resume != "y" && resume != "Y" {
+		os.Remove(s.stateFile)
+		return nil
+	}
+	boards := make([]game.Playable, len(saved))
+	for i, sg := range saved {
+		board, err := sg.Restore(answers)
+		if err != nil {
+			fmt.Fprintf(output, "Could not resume game: %v\n", err)
+			os.Remove(s.stateFile)
+			return nil
+		}
+		boards[i] = board
+	}
+	g := game.NewMultiGame(boards...)
+	fmt.Fprintln(output, "Resuming your game:")
+	for round := 0; round < g.Guesses(); round++ {
+		results := make([][]game.LetterResult, len(boards))
+		for i, board := range boards {
+			if history := board.History(); round < len(history) {
+				results[i] = history[round]
+			}
+		}
+		printResults(output, "", results, saved[0].WordLength)
+		fmt.Fprintln(output)
+	}
+	printKeyboards(output, g.Keyboards(), layout(s))
+	return g
+}
+
+// resumable reports whether saved was written by a game with the settings of
+// s, so that another mode, board count or day's puzzle is never resumed.
+func resumable(saved []*game.SavedGame, s settings) bool {
+	if len(saved) != s.boards {
+		return false
+	}
+	for _, sg := range saved {
+		if sg.WordLength != s.wordLength || sg.Mode != s.mode || sg.Boards != s.boards || sg.Puzzle != puzzle(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// puzzle returns today's daily puzzle number in daily mode and 0 otherwise.
+func puzzle(s settings) int {
+	if s.mode != game.Daily {
+		return 0
+	}
+	return game.DailyNumber(time.Now())
+}
+
+// saveGame writes the boards of an unfinished classic game to the state file
+// and removes the file once the game is over. Games with other feedback are
+// not saved, since the save format does not record the evaluator.
+func saveGame(s settings, g *game.MultiGame) error {
+	if s.stateFile == "" || s.feedback != game.WordleFeedback {
+		return nil
+	}
+	if g.Status() != game.InProgress {
+		err := os.Remove(s.stateFile)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	saved := make([]*game.SavedGame, len(g.Boards))
+	for i, board := range g.Boards {
+		classic, ok := board.(*game.Game)
+		if !ok {
+			return nil
+		}
+		sg, err := classic.Save()
+		if err != nil {
+			return err
+		}
+		sg.Mode = s.mode
+		sg.Boards = len(g.Boards)
+		sg.Puzzle = puzzle(s)
+		saved[i] = sg
+	}
+	if err := os.MkdirAll(filepath.Dir(s.stateFile), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(s.stateFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return game.WriteSavedGames(file, saved)
+}
+
+// defaultStateFile returns the per-user file unfinished games are saved to.
+func defaultStateFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "wordle", "standalone.json")
+}
+
+// printResults prints the feedback for guess on every board side by side.
+// Boards that were already solved are left blank.
+func printResults(output io.Writer, guess string, results [][]game.LetterResult, wordLength int) {
+	for i, result := range results {
+		if i > 0 {
+			fmt.Fprint(output, "| ")
+		}
+		if result == nil {
+			fmt.Fprint(output, strings.Repeat(" ", 4*wordLength))
+			continue
+		}
+		fmt.Fprint(output, game.FormatRow(guess, result))
+	}
+}
+
+// printKeyboards prints the keyboard of every board side by side.
+func printKeyboards(output io.Writer, keyboards []game.Keyboard, layout []string) {
+	rows := make([][]string, len(keyboards))
+	for i, keyboard := range keyboards {
+		rows[i] = keyboard.RowsOf(layout)
+	}
+	for line := range layout {
+		for i := range keyboards {
+			if i > 0 {
+				fmt.Fprint(output, "| ")
+			}
+			fmt.Fprint(output, rows[i][line])
+		}
+		fmt.Fprintln(output)
+	}
+}
+
+// printHints prints the solver's best next guesses for every unsolved board.
+func printHints(output io.Writer, hints []*solver.Solver, g *game.MultiGame) {
+	for i, board := range g.Boards {
+		if board.Status() != game.InProgress {
+			continue
+		}
+		hints[i].Update(board.History())
+		if len(g.Boards) > 1 {
+			fmt.Fprintf(output, "Board %d: ", i+1)
+		}
+		fmt.Fprintf(output, "%d possible answers left. Suggestions:\n", len(hints[i].Candidates()))
+		for _, suggestion := range hints[i].Suggest(3) {
+			fmt.Fprintf(output, "  %s (%.2f bits, at most %d left)\n", suggestion.Word, suggestion.Entropy, suggestion.Worst)
+		}
+	}
+}
+
 func main() {
 	var maxGuessesInt int
 	if mg, err := strconv.Atoi(MaxGuesses); err == nil {
 		maxGuessesInt = mg
 	}
-	RunGame(os.Stdin, os.Stdout, WordListPath, maxGuessesInt)
+	wordLengthInt := game.DefaultWordLength
+	if wl, err := strconv.Atoi(WordLength); err == nil && game.IsValidLength(wl) {
+		wordLengthInt = wl
+	}
+	mode, err := game.ParseMode(Mode)
+	if err != nil {
+		mode = game.Classic
+	}
+	boardsInt := 1
+	if b, err := strconv.Atoi(Boards); err == nil && b >= 1 && mode != game.Absurd {
+		boardsInt = b
+	}
+	hardModeBool, _ := strconv.ParseBool(HardMode)
+	feedback, err := game.ParseFeedback(Feedback)
+	if err != nil || (mode == game.Absurd && feedback == game.LyingFeedback) {
+		feedback = game.WordleFeedback
+	}
+	tier, err := game.ParseTier(Tier)
+	if err != nil {
+		tier = game.AnyTier
+	}
+	language, err := game.LookupLanguage(Language)
+	if err != nil {
+		language = game.English
+	}
+	wordListPath, allowedGuessesPath := languagePaths(language)
+	opts := []Option{
+		WithLanguage(language),
+		WithWordLength(wordLengthInt),
+		WithMode(mode),
+		WithBoards(boardsInt),
+		WithDailySecret(DailySecret),
+		WithHardMode(hardModeBool),
+		WithFeedback(feedback),
+		WithTier(tier),
+	}
+	if allowedGuessesPath != "" {
+		opts = append(opts, WithAllowedGuesses(allowedGuessesPath))
+	}
+	if BlocklistPath != "" {
+		opts = append(opts, WithBlocklist(BlocklistPath))
+	}
+	stateFile := StateFile
+	if stateFile == "" {
+		stateFile = defaultStateFile()
+	}
+	opts = append(opts, WithStateFile(stateFile))
+	if seed, err := strconv.ParseInt(Seed, 10, 64); err == nil {
+		opts = append(opts, WithSource(rand.NewSource(seed)))
+	}
+	RunGame(os.Stdin, os.Stdout, wordListPath, maxGuessesInt, opts...)
+}
+
+// languagePaths swaps the default English list paths for the language's own.
+func languagePaths(language *game.Language) (string, string) {
+	wordListPath, allowedGuessesPath := WordListPath, AllowedGuessesPath
+	if wordListPath == game.English.WordListPath {
+		wordListPath = language.WordListPath
+	}
+	if allowedGuessesPath == game.English.AllowedGuessesPath {
+		allowedGuessesPath = language.AllowedGuessesPath
+	}
+	return wordListPath, allowedGuessesPath
 }
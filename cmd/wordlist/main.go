@@ -0,0 +1,210 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/tomlaws/wordle/internal/game"
+	"github.com/tomlaws/wordle/internal/solver"
+	"github.com/tomlaws/wordle/internal/wordlist"
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+const usage = `Usage:
+  wordlist lint [-length n | -mixed] file...
+  wordlist build [-o file] [-blocklist file] file...
+  wordlist stats file...
+  wordlist rate [-o file] [-allowed file] [-language code] file
+`
+
+// errProblems reports that lint found problems, which it has printed.
+var errProblems = errors.New("problems found")
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "lint":
+		err = lint(os.Args[2:])
+	case "build":
+		err = build(os.Args[2:])
+	case "stats":
+		err = stats(os.Args[2:])
+	case "rate":
+		err = rate(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "wordlist:", err)
+		os.Exit(1)
+	}
+}
+
+// lint prints the problems of every file.
+func lint(args []string) error {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	length := flags.Int("length", 0, "report words that do not have this many letters, instead of the most common length")
+	mixed := flags.Bool("mixed", false, "accept words of every supported length")
+	flags.Parse(args)
+	if *mixed {
+		*length = wordlist.AnyLength
+	}
+	found := false
+	for _, path := range flags.Args() {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		problems, err := wordlist.Lint(file, *length)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for _, problem := range problems {
+			fmt.Printf("%s:%s\n", path, strings.TrimPrefix(problem.String(), "line "))
+		}
+		found = found || len(problems) > 0
+	}
+	if found {
+		return errProblems
+	}
+	return nil
+}
+
+// build merges the files into one sorted list without duplicates or blocked
+// words.
+func build(args []string) error {
+	flags := flag.NewFlagSet("build", flag.ExitOnError)
+	output := flags.String("o", "", "write the list to this file instead of standard output")
+	blocklist := flags.String("blocklist", "", "leave out the words in this file")
+	flags.Parse(args)
+	var lists [][]utils.WordEntry
+	for _, path := range flags.Args() {
+		entries, err := utils.LoadWordEntries(path)
+		if err != nil {
+			return err
+		}
+		lists = append(lists, entries)
+	}
+	var blocked []string
+	if *blocklist != "" {
+		var err error
+		if blocked, err = utils.LoadWords(*blocklist); err != nil {
+			return err
+		}
+	}
+	return writeList(*output, wordlist.Build(lists, blocked))
+}
+
+// stats prints the word counts and letter frequencies of the files combined.
+func stats(args []string) error {
+	var words []string
+	for _, path := range args {
+		list, err := utils.LoadWords(path)
+		if err != nil {
+			return err
+		}
+		words = append(words, list...)
+	}
+	s := wordlist.Summarize(words)
+	fmt.Printf("%d words\n", s.Words)
+	lengths := make([]int, 0, len(s.ByLength))
+	for length := range s.ByLength {
+		lengths = append(lengths, length)
+	}
+	sort.Ints(lengths)
+	for _, length := range lengths {
+		fmt.Printf("  %2d letters: %d\n", length, s.ByLength[length])
+	}
+	fmt.Printf("Words containing each letter:\n  %s\n", topLetters(s.Letters, s.Words, 26))
+	fmt.Println("Most common letters by position:")
+	for i, counts := range s.Positions {
+		total := 0
+		for _, count := range counts {
+			total += count
+		}
+		fmt.Printf("  %2d: %s\n", i+1, topLetters(counts, total, 5))
+	}
+	return nil
+}
+
+// topLetters formats the n most common letters with their share of total.
+func topLetters(counts map[rune]int, total, n int) string {
+	letters := make([]rune, 0, len(counts))
+	for letter := range counts {
+		letters = append(letters, letter)
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		if counts[letters[i]] != counts[letters[j]] {
+			return counts[letters[i]] > counts[letters[j]]
+		}
+		return letters[i] < letters[j]
+	})
+	if len(letters) > n {
+		letters = letters[:n]
+	}
+	parts := make([]string, len(letters))
+	for i, letter := range letters {
+		parts[i] = fmt.Sprintf("%c %.1f%%", letter, 100*float64(counts[letter])/float64(total))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// rate stores the guesses the solver needs for every word as its difficulty.
+func rate(args []string) error {
+	flags := flag.NewFlagSet("rate", flag.ExitOnError)
+	output := flags.String("o", "", "write the list to this file instead of standard output")
+	allowed := flags.String("allowed", "", "let the solver guess the words in this file too")
+	code := flags.String("language", game.English.Code, "normalize the words with this language")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		return errors.New("rate takes one word list")
+	}
+	language, err := game.LookupLanguage(*code)
+	if err != nil {
+		return err
+	}
+	entries, err := utils.LoadWordEntries(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	opts := []game.WordListOption{game.WithLanguage(language)}
+	if *allowed != "" {
+		opts = append(opts, game.WithAllowedGuesses(*allowed))
+	}
+	list, err := game.NewWordList(flags.Arg(0), opts...)
+	if err != nil {
+		return err
+	}
+	for i, entry := range entries {
+		// Hard mode only guesses possible answers, like most players do.
+		if guesses := solver.Rate(list, language.Normalize(entry.Word), solver.WithHardMode(true)); guesses > 0 {
+			entries[i].Difficulty = float64(guesses)
+		}
+	}
+	return writeList(*output, entries)
+}
+
+// writeList writes entries to path, or to standard output if path is empty.
+func writeList(path string, entries []utils.WordEntry) error {
+	var w io.Writer = os.Stdout
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	return wordlist.Write(w, entries)
+}
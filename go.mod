@@ -2,7 +2,14 @@ module github.com/tomlaws/wordle
 
 go 1.25.0
 
+require (
+	github.com/fxamacker/cbor/v2 v2.9.2
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
 require (
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/gorilla/websocket v1.5.3 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
 )
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tomlaws/wordle/internal/game"
+	"github.com/tomlaws/wordle/internal/multiplayer"
+	"github.com/tomlaws/wordle/internal/protocol"
+	"github.com/tomlaws/wordle/internal/solver"
+)
+
+// NewBot creates a bot that guesses from the list wordList returns at the
+// start of each match, such as Lobby.WordList, after waiting thinkTime on
+// each of its turns. The bot is ready to be passed to Lobby.NewPlayer.
+func NewBot(nickname string, wordList func() *game.WordList, thinkTime time.Duration) *Bot {
+	b := &Bot{
+		id:        uuid.New().String(),
+		nickname:  nickname,
+		wordList:  wordList,
+		thinkTime: thinkTime,
+		incoming:  make(chan []byte),
+		outgoing:  make(chan []byte),
+		error:     make(chan error),
+	}
+	go b.play()
+	return b
+}
+
+func (b *Bot) ID() string {
+	return b.id
+}
+
+func (b *Bot) Nickname() string {
+	return b.nickname
+}
+
+// Incoming carries the bot's messages to the lobby.
+func (b *Bot) Incoming() chan []byte {
+	return b.incoming
+}
+
+// Outgoing carries the lobby's messages to the bot.
+func (b *Bot) Outgoing() chan []byte {
+	return b.outgoing
+}
+
+func (b *Bot) Error() chan error {
+	return b.error
+}
+
+// Codec returns the encoding of the bot's messages. Bots play in process, so
+// JSON costs nothing on the wire.
+func (b *Bot) Codec() protocol.Codec {
+	return protocol.JSON
+}
+
+// Disconnect does nothing: bots follow the protocol.
+func (b *Bot) Disconnect(reason string) {
+}
+
+func (b *Bot) play() {
+	p := protocol.NewProtocol(multiplayer.PayloadRegistry)
+	messages := p.UnwrapChannel(b.outgoing)
+	replies := p.WrapChannel(b.incoming)
+	var boards []*board
+	lies := false
+	rejected := make(map[string]bool)
+	reply := func(payload protocol.Payload, delay time.Duration) {
+		go func() {
+			time.Sleep(delay)
+			replies <- payload
+		}()
+	}
+	for msg := range messages {
+		switch msg := msg.(type) {
+		case *multiplayer.GameStartPayload:
+			lies = msg.Feedback == game.LyingFeedback
+			boards = make([]*board, max(msg.Boards, 1))
+			for i := range boards {
+				boards[i] = &board{solver: solver.New(b.wordList(), msg.WordLength,
+					solver.WithHardMode(msg.HardMode),
+					solver.WithLies(lies),
+				)}
+			}
+			rejected = make(map[string]bool)
+		case *multiplayer.FeedbackPayload:
+			if msg.Board < 0 || msg.Board >= len(boards) {
+				continue
+			}
+			bd := boards[msg.Board]
+			bd.history = append(bd.history, msg.Feedback)
+			bd.solver.Update(bd.history)
+			// A lie can hide a solved row, but the board then stops
+			// receiving feedback, which target catches.
+			bd.solved = !lies && solved(msg.Feedback)
+		case *multiplayer.InvalidWordPayload:
+			if msg.Player.ID == b.id {
+				rejected[msg.Word] = true
+				b.guess(target(boards), rejected, reply)
+			}
+		case *multiplayer.RoundStartPayload:
+			if msg.Player.ID == b.id {
+				b.guess(target(boards), rejected, reply)
+			}
+		case *multiplayer.GameOverPayload:
+			reply(&multiplayer.PlayAgainPayload{Confirm: true}, 0)
+		}
+	}
+}
+
+// target returns the solver of the unsolved board with the fewest candidates
+// left, or nil before the first match starts. Boards that fell behind the
+// others stopped receiving feedback, so they are solved too.
+func target(boards []*board) *solver.Solver {
+	rows := 0
+	for _, bd := range boards {
+		rows = max(rows, len(bd.history))
+	}
+	var best *board
+	for _, bd := range boards {
+		if bd.solved || len(bd.history) < rows {
+			continue
+		}
+		if best == nil || len(bd.solver.Candidates()) < len(best.solver.Candidates()) {
+			best = bd
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.solver
+}
+
+// solved reports whether every tile of row is a Hit.
+func solved(row []game.LetterResult) bool {
+	for _, lr := range row {
+		if lr.MatchType != game.Hit {
+			return false
+		}
+	}
+	return len(row) > 0
+}
+
+// guess sends the best suggestion that the lobby has not already rejected.
+func (b *Bot) guess(s *solver.Solver, rejected map[string]bool, reply func(protocol.Payload, time.Duration)) {
+	if s == nil {
+		return
+	}
+	for _, suggestion := range s.Suggest(len(rejected) + 1) {
+		if !rejected[suggestion.Word] {
+			log.Printf("Bot %s guesses %s", b.nickname, suggestion.Word)
+			reply(&multiplayer.GuessPayload{Word: suggestion.Word}, b.thinkTime)
+			return
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/tomlaws/wordle/internal/multiplayer"
+	"github.com/tomlaws/wordle/internal/protocol"
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+// tap passes the lobby's messages on to a bot and keeps a decoded copy.
+type tap struct {
+	*Bot
+	outgoing chan []byte
+	messages chan protocol.Payload
+}
+
+func newTap(b *Bot) *tap {
+	t := &tap{
+		Bot:      b,
+		outgoing: make(chan []byte),
+		messages: make(chan protocol.Payload, 256),
+	}
+	go func() {
+		for data := range t.outgoing {
+			if msgType, raw, err := protocol.JSON.Decode(data); err == nil {
+				if newPayload, ok := multiplayer.PayloadRegistry[msgType]; ok {
+					payload := newPayload()
+					if protocol.JSON.Unmarshal(raw, payload) == nil {
+						select {
+						case t.messages <- payload:
+						default:
+						}
+					}
+				}
+			}
+			b.outgoing <- data
+		}
+	}()
+	return t
+}
+
+func (t *tap) Outgoing() chan []byte {
+	return t.outgoing
+}
+
+func TestBot_PlaysFullMatch(t *testing.T) {
+	for _, boards := range []int{1, 2} {
+		t.Run(fmt.Sprintf("%d boards", boards), func(t *testing.T) {
+			lobby := multiplayer.NewLobby(path.Join(utils.Root, "assets", "words.txt"), 6+boards, 30,
+				multiplayer.WithBoards(boards),
+				multiplayer.WithMatchSeed(1),
+			)
+			watched := newTap(NewBot("Bot 1", lobby.WordList, 0))
+			lobby.NewPlayer(watched)
+			lobby.NewPlayer(NewBot("Bot 2", lobby.WordList, 0))
+
+			wordList := lobby.WordList()
+			rounds := make(map[int]string)
+			timeout := time.After(20 * time.Second)
+			for {
+				select {
+				case msg := <-watched.messages:
+					switch msg := msg.(type) {
+					case *multiplayer.InvalidWordPayload:
+						t.Errorf("%s guessed invalid word %q: %s", msg.Player.Nickname, msg.Word, msg.Reason)
+					case *protocol.ErrorPayload:
+						t.Errorf("Lobby rejected a message: %s", msg.Message)
+					case *multiplayer.FeedbackPayload:
+						if !wordList.IsValidWord(msg.Word) {
+							t.Errorf("%s guessed %q, which is not in the word list", msg.Player.Nickname, msg.Word)
+						}
+						// Multi-board matches send one payload per board.
+						if _, ok := rounds[msg.Round]; ok {
+							continue
+						}
+						if slices.Contains(slices.Collect(maps.Values(rounds)), msg.Word) {
+							t.Errorf("%s repeated %q instead of using the feedback", msg.Player.Nickname, msg.Word)
+						}
+						rounds[msg.Round] = msg.Word
+					case *multiplayer.GameOverPayload:
+						if msg.Winner == nil {
+							t.Errorf("Expected the bots to solve %v", msg.Answers)
+						}
+						return
+					}
+				case <-timeout:
+					t.Fatal("Match did not finish")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/tomlaws/wordle/internal/game"
+	"github.com/tomlaws/wordle/internal/solver"
+)
+
+// Bot is a computer opponent that joins a lobby like any other client and
+// picks its guesses with the solver.
+type Bot struct {
+	id        string
+	nickname  string
+	wordList  func() *game.WordList
+	thinkTime time.Duration
+	incoming  chan []byte
+	outgoing  chan []byte
+	error     chan error
+}
+
+// board tracks what the bot knows about one board of a match.
+type board struct {
+	solver  *solver.Solver
+	history [][]game.LetterResult
+	solved  bool
+}
@@ -2,37 +2,85 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 
 	"github.com/gorilla/websocket"
+	"github.com/tomlaws/wordle/internal/protocol"
 )
 
-func NewClient(ipAddress string, nickname string) (*Client, error) {
+// NewClient connects to the server and says hello with features. It fails
+// with the server's reason if the server turns the client away.
+func NewClient(ipAddress string, nickname string, features protocol.Features) (*Client, error) {
 	url := url.URL{Scheme: "ws", Host: ipAddress, Path: "/socket", RawQuery: fmt.Sprintf("nickname=%s", nickname)}
-	conn, _, err := websocket.DefaultDialer.Dial(url.String(), nil)
+	dialer := *websocket.DefaultDialer
+	dialer.EnableCompression = true
+	conn, _, err := dialer.Dial(url.String(), nil)
 	if err != nil {
 		return nil, err
 	}
+	welcome, err := handshake(conn, features)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	codec, err := protocol.LookupCodec(welcome.Encoding)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.EnableWriteCompression(welcome.Compression == protocol.CompressionDeflate)
 	client := &Client{
 		url:      url,
 		conn:     conn,
-		incoming: make(chan json.RawMessage),
-		outgoing: make(chan json.RawMessage),
+		incoming: make(chan []byte),
+		outgoing: make(chan []byte),
 		error:    make(chan error),
+		welcome:  welcome,
+		codec:    codec,
 	}
 	go handleRead(client)
 	go handleWrite(client)
 	return client, nil
 }
 
+func handshake(conn *websocket.Conn, features protocol.Features) (*protocol.WelcomePayload, error) {
+	hello, err := protocol.NewMessage(&protocol.HelloPayload{
+		Version:  protocol.Version,
+		Features: features,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.WriteJSON(hello); err != nil {
+		return nil, err
+	}
+	var msg protocol.Message
+	if err := conn.ReadJSON(&msg); err != nil {
+		var closeErr *websocket.CloseError
+		if errors.As(err, &closeErr) && closeErr.Text != "" {
+			return nil, fmt.Errorf("server refused the connection: %s", closeErr.Text)
+		}
+		return nil, err
+	}
+	if msg.Type != protocol.MsgTypeWelcome {
+		return nil, fmt.Errorf("expected welcome, got %s", msg.Type)
+	}
+	var welcome protocol.WelcomePayload
+	if err := json.Unmarshal(msg.Payload, &welcome); err != nil {
+		return nil, fmt.Errorf("malformed welcome: %w", err)
+	}
+	return &welcome, nil
+}
+
 func handleRead(client *Client) {
 	defer func() {
 		close(client.incoming)
 	}()
 	for {
-		var msg json.RawMessage
-		if err := client.conn.ReadJSON(&msg); err != nil {
+		_, msg, err := client.conn.ReadMessage()
+		if err != nil {
 			client.error <- err
 			// log.Printf("Error reading message from server: %v", err)
 			break
@@ -48,7 +96,11 @@ func handleWrite(client *Client) {
 	}()
 	for {
 		msg := <-client.outgoing
-		if err := client.conn.WriteJSON(msg); err != nil {
+		frameType := websocket.TextMessage
+		if client.codec.Binary() {
+			frameType = websocket.BinaryMessage
+		}
+		if err := client.conn.WriteMessage(frameType, msg); err != nil {
 			client.error <- err
 			// log.Printf("Error sending message to server: %v", err)
 			break
@@ -57,11 +109,11 @@ func handleWrite(client *Client) {
 	}
 }
 
-func (c *Client) Incoming() chan json.RawMessage {
+func (c *Client) Incoming() chan []byte {
 	return c.incoming
 }
 
-func (c *Client) Outgoing() chan json.RawMessage {
+func (c *Client) Outgoing() chan []byte {
 	return c.outgoing
 }
 
@@ -69,6 +121,16 @@ func (c *Client) Err() chan error {
 	return c.error
 }
 
+// Welcome returns what the client and server agreed on in the handshake.
+func (c *Client) Welcome() *protocol.WelcomePayload {
+	return c.welcome
+}
+
+// Codec returns the encoding agreed on in the handshake.
+func (c *Client) Codec() protocol.Codec {
+	return c.codec
+}
+
 func (c *Client) Stop() {
 	c.conn.Close()
 }
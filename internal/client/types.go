@@ -5,14 +5,17 @@ import (
 	"net/url"
 
 	"github.com/gorilla/websocket"
+	"github.com/tomlaws/wordle/internal/protocol"
 )
 
 type Client struct {
 	url      url.URL
 	conn     *websocket.Conn
-	incoming chan json.RawMessage
-	outgoing chan json.RawMessage
+	incoming chan []byte
+	outgoing chan []byte
 	error    chan error
+	welcome  *protocol.WelcomePayload
+	codec    protocol.Codec
 }
 
 type Message struct {
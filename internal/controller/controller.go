@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/tomlaws/wordle/internal/client"
 	"github.com/tomlaws/wordle/internal/game"
@@ -11,11 +12,23 @@ import (
 	"github.com/tomlaws/wordle/internal/protocol"
 )
 
+// Features lists what the console client can play and decode.
+var Features = protocol.Features{
+	Variants: []string{
+		string(game.Classic), string(game.Absurd), string(game.Daily), string(game.Fibble), string(game.Nerdle),
+		string(game.WordleFeedback), string(game.MastermindFeedback), string(game.PeaksFeedback), string(game.LyingFeedback),
+		multiplayer.VariantMultiBoard,
+		game.English.Code, game.Spanish.Code, game.German.Code,
+	},
+	Encodings:   []string{protocol.EncodingCBOR, protocol.EncodingMessagePack, protocol.EncodingJSON},
+	Compression: []string{protocol.CompressionDeflate, protocol.CompressionNone},
+}
+
 func NewController(client *client.Client) *Controller {
 	defer func() {
 		client.Stop()
 	}()
-	protocol := protocol.NewProtocol(multiplayer.PayloadRegistry)
+	protocol := protocol.NewProtocol(multiplayer.PayloadRegistry, protocol.WithCodec(client.Codec()))
 	controller := &Controller{
 		input:        make(chan Input),
 		inputTrigger: make(chan InputTrigger),
@@ -62,6 +75,10 @@ func (c *Controller) start() error {
 	var err error
 	var me Me
 	var maxGuesses int
+	var wordLength int
+	var boards int
+	var mode game.Mode
+	language := game.English
 	var currentRound int
 	var isOddPlayer bool
 	for {
@@ -76,10 +93,19 @@ func (c *Controller) start() error {
 				fmt.Fprintf(output, "Welcome to Wordle, %s!\n", me.Nickname)
 			case *multiplayer.MatchingPayload:
 				fmt.Fprintf(output, "Finding opponent...\n")
+			case *protocol.ErrorPayload:
+				fmt.Fprintf(output, "The server rejected a message (%s): %s\n", msg.Code, msg.Message)
 			case *multiplayer.GameStartPayload:
 				// Handle game start
 				currentRound = 1
 				maxGuesses = msg.MaxGuesses
+				wordLength = msg.WordLength
+				boards = msg.Boards
+				mode = msg.Mode
+				language = game.English
+				if l, err := game.LookupLanguage(msg.Language); err == nil {
+					language = l
+				}
 				isOddPlayer = msg.Player1.ID == me.ID
 				var opponent *multiplayer.Player
 				if isOddPlayer {
@@ -88,7 +114,33 @@ func (c *Controller) start() error {
 					opponent = msg.Player1
 				}
 				fmt.Fprintf(output, "You are playing against %s\n", opponent.Nickname)
-				fmt.Fprintln(output, "Guess the 5-letter word in", maxGuesses, "rounds.")
+				if mode == game.Nerdle {
+					fmt.Fprintf(output, "Guess the %d-character equation, such as 12+35=47, in %d rounds.\n", wordLength, maxGuesses)
+				} else if boards > 1 {
+					fmt.Fprintf(output, "Guess all %d of the %d-letter words in %d rounds.\n", boards, wordLength, maxGuesses)
+				} else {
+					fmt.Fprintf(output, "Guess the %d-letter word in %d rounds.\n", wordLength, maxGuesses)
+				}
+				if msg.Mode == game.Daily {
+					fmt.Fprintf(output, "Daily #%d\n", msg.Puzzle)
+				}
+				if msg.Mode == game.Absurd {
+					fmt.Fprintln(output, "Absurd mode is on: the answer keeps changing to dodge your guesses.")
+				}
+				switch msg.Feedback {
+				case game.MastermindFeedback:
+					fmt.Fprintln(output, "Mastermind feedback: only the number of [hits] and (present) letters is shown.")
+				case game.PeaksFeedback:
+					fmt.Fprintln(output, "Peaks feedback: arrows show whether the letter in each position comes later or earlier in the alphabet.")
+				case game.LyingFeedback:
+					fmt.Fprintln(output, "Lying feedback: one tile in every row is false.")
+				}
+				if msg.Tier != "" && msg.Tier != game.AnyTier {
+					fmt.Fprintf(output, "Difficulty: %s\n", msg.Tier)
+				}
+				if msg.HardMode {
+					fmt.Fprintln(output, "Hard mode is on: revealed hints must be used in every guess.")
+				}
 			case *multiplayer.RoundStartPayload:
 				sameRound := msg.Round == currentRound
 				currentRound = msg.Round
@@ -107,7 +159,13 @@ func (c *Controller) start() error {
 				}
 			case *multiplayer.InvalidWordPayload:
 				if msg.Player.ID == me.ID {
-					fmt.Fprintln(output, "Invalid word. Please try again.")
+					if msg.Reason != "" {
+						fmt.Fprintf(output, "Invalid word (%s). Please try again.\n", msg.Reason)
+					} else {
+						fmt.Fprintln(output, "Invalid word. Please try again.")
+					}
+					fmt.Fprintf(output, "Enter your guess (%d/%d): ", currentRound, maxGuesses)
+					c.inputTrigger <- InputTrigger{Category: GuessWord}
 				} else {
 					fmt.Fprintf(output, "Opponent guessed an invalid word: %s\n", msg.Word)
 				}
@@ -118,26 +176,41 @@ func (c *Controller) start() error {
 				} else {
 					fmt.Printf("Opponent guessed: ")
 				}
+				if boards > 1 {
+					fmt.Fprintf(output, "(board %d) ", msg.Board+1)
+				}
 				currentRound = msg.Round + 1
 				// Display feedback to the user
-				for _, lr := range msg.Feedback {
-					switch lr.MatchType {
-					case game.Hit:
-						fmt.Fprintf(output, "[%c] ", lr.Letter)
-					case game.Present:
-						fmt.Fprintf(output, "(%c) ", lr.Letter)
-					case game.Miss:
-						fmt.Fprintf(output, " %c  ", lr.Letter)
+				fmt.Fprintln(output, game.FormatRow(msg.Word, msg.Feedback))
+				if msg.Keyboard != nil {
+					layout := language.Layout
+					if mode == game.Nerdle {
+						layout = game.EquationLayout
+					}
+					for _, row := range msg.Keyboard.RowsOf(layout) {
+						fmt.Fprintln(output, row)
 					}
 				}
-				fmt.Fprintln(output)
 			case *multiplayer.GameOverPayload:
+				answer := msg.Answer
+				if len(msg.Answers) > 0 {
+					answer = strings.Join(msg.Answers, ", ")
+				}
 				if msg.Winner == nil {
-					fmt.Fprintln(output, "It's a draw! The correct word was:", msg.Answer)
+					fmt.Fprintln(output, "It's a draw! The correct word was:", answer)
 				} else if msg.Winner.ID == me.ID {
 					fmt.Fprintln(output, "Congratulations! You've won!")
 				} else {
-					fmt.Fprintln(output, "You've lost! The correct word was:", msg.Answer)
+					fmt.Fprintln(output, "You've lost! The correct word was:", answer)
+				}
+				for _, lie := range msg.Lies {
+					if boards > 1 {
+						fmt.Fprintf(output, "Board %d, ", lie.Board+1)
+					}
+					fmt.Fprintf(output, "Guess %d: %v\n", lie.Attempt+1, lie)
+				}
+				if msg.Share != "" {
+					fmt.Fprintf(output, "\n%s\n\n", msg.Share)
 				}
 
 				// Ask for a new game
@@ -157,13 +230,29 @@ func (c *Controller) start() error {
 			category := input.Category
 			switch category {
 			case GuessWord:
-				// Check if text is 5
-				if len(input.Text) != 5 {
-					fmt.Fprintln(output, "Invalid input. Please enter a 5-letter word.")
+				if mode != game.Nerdle {
+					input.Text = language.Normalize(input.Text)
+				}
+				// Check if text has the expected length
+				if game.Length(input.Text) != wordLength {
+					if mode == game.Nerdle {
+						fmt.Fprintf(output, "Invalid input. Please enter an equation of %d characters.\n", wordLength)
+					} else {
+						fmt.Fprintf(output, "Invalid input. Please enter a %d-letter word.\n", wordLength)
+					}
 					fmt.Fprintf(output, "Enter your guess (%d/%d): ", currentRound, maxGuesses)
 					c.inputTrigger <- InputTrigger{Category: GuessWord}
 					continue
 				}
+				// Equations can be checked without asking the server
+				if mode == game.Nerdle {
+					if err := game.CheckEquation(input.Text); err != nil {
+						fmt.Fprintf(output, "Invalid equation (%v). Please try again.\n", err)
+						fmt.Fprintf(output, "Enter your guess (%d/%d): ", currentRound, maxGuesses)
+						c.inputTrigger <- InputTrigger{Category: GuessWord}
+						continue
+					}
+				}
 				// Handle guess word input
 				guessPayload := multiplayer.GuessPayload{
 					Word: input.Text,
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"strings"
+)
+
+// NewAbsurdGame creates an adversarial game over candidates, which must all
+// have the same length.
+func NewAbsurdGame(candidates []string, maxGuesses int, opts ...Option) *AbsurdGame {
+	answer := ""
+	if len(candidates) > 0 {
+		answer = candidates[0]
+	}
+	a := &AbsurdGame{
+		Game:       *NewGame(answer, maxGuesses, opts...),
+		Candidates: append([]string(nil), candidates...),
+	}
+	return a
+}
+
+// MakeGuess groups the remaining candidates by the feedback guess would get
+// and keeps the largest group. The evaluator is called once per candidate,
+// so it should not be a LyingEvaluator. Ties go to the group that reveals the least,
+// so the guess only wins once it is the last candidate left.
+func (a *AbsurdGame) MakeGuess(guess string) ([]LetterResult, error) {
+	if err := a.Validate(guess); err != nil {
+		return nil, err
+	}
+	buckets := make(map[int][]string)
+	for _, candidate := range a.Candidates {
+		pattern := Pattern(a.evaluate(guess, candidate))
+		buckets[pattern] = append(buckets[pattern], candidate)
+	}
+	best := -1
+	for pattern, bucket := range buckets {
+		if best == -1 || a.worse(pattern, bucket, best, buckets[best]) {
+			best = pattern
+		}
+	}
+	a.Candidates = buckets[best]
+	a.Answer = a.Candidates[0]
+	for _, candidate := range a.Candidates {
+		if strings.EqualFold(candidate, guess) {
+			a.Answer = candidate
+		}
+	}
+	result := a.evaluate(guess, a.Answer)
+	a.record(guess, result)
+	return result, nil
+}
+
+// worse reports whether the feedback pattern p leaves the player worse off
+// than the pattern q: it keeps more candidates or, failing that, fixes fewer
+// letters in place.
+func (a *AbsurdGame) worse(p int, pBucket []string, q int, qBucket []string) bool {
+	if len(pBucket) != len(qBucket) {
+		return len(pBucket) > len(qBucket)
+	}
+	if ph, qh := hits(p), hits(q); ph != qh {
+		return ph < qh
+	}
+	return p < q
+}
+
+// hits counts the Hit tiles in a pattern built by Pattern.
+func hits(pattern int) int {
+	n := 0
+	for ; pattern > 0; pattern /= matchTypes {
+		if MatchType(pattern%matchTypes) == Hit {
+			n++
+		}
+	}
+	return n
+}
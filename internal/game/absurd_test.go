@@ -0,0 +1,73 @@
+package game
+
+import (
+	"path"
+	"testing"
+
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+func TestAbsurdGame_KeepsLargestBucket(t *testing.T) {
+	candidates := []string{"apple", "grape", "lemon", "melon"}
+	game := NewAbsurdGame(candidates, 6)
+	_, err := game.MakeGuess("lemon")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// "lemon" splits the candidates into four single-word groups; ties go
+	// to the feedback revealing the least, which is grape's lone Present.
+	if len(game.Candidates) != 1 || game.Candidates[0] != "grape" {
+		t.Errorf("Expected only grape to remain, got %v", game.Candidates)
+	}
+	if game.Status() != InProgress {
+		t.Errorf("Expected game to continue, got %v", game.Status())
+	}
+}
+
+func TestAbsurdGame_WinsOnLastCandidate(t *testing.T) {
+	game := NewAbsurdGame([]string{"apple"}, 6)
+	if _, err := game.MakeGuess("apple"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if game.Status() != Won || game.Solution() != "apple" {
+		t.Errorf("Expected win with apple, got %v %s", game.Status(), game.Solution())
+	}
+}
+
+func TestAbsurdGame_PeaksTieAvoidsHits(t *testing.T) {
+	game := NewAbsurdGame([]string{"mango", "apple"}, 6, WithEvaluator(EvaluatorFunc(ScorePeaks)))
+	if _, err := game.MakeGuess("mango"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Both groups hold one word; the tie must not go to the all-Hit row.
+	if game.Status() != InProgress || game.Solution() != "apple" {
+		t.Errorf("Expected apple to remain, got %v %s", game.Status(), game.Solution())
+	}
+}
+
+func TestAbsurdGame_FeedbackStaysConsistent(t *testing.T) {
+	wordList, err := NewWordList(path.Join(utils.Root, "assets", "words.txt"))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	var game Playable = NewAbsurdGame(wordList.Answers(5), 6)
+	for _, guess := range []string{"table", "ghost", "river"} {
+		if _, err := game.MakeGuess(guess); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	// Whatever answer the adversary ends with must explain every row.
+	k := NewKnowledge(5, game.History())
+	if !k.IsConsistent(game.Solution()) {
+		t.Errorf("Solution %s is inconsistent with the feedback", game.Solution())
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	if mode, err := ParseMode("Absurd"); err != nil || mode != Absurd {
+		t.Errorf("Expected absurd mode, got %v %v", mode, err)
+	}
+	if _, err := ParseMode("unknown"); err == nil {
+		t.Errorf("Expected error for unknown mode")
+	}
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+// loadBlocklist loads the blocked words of config, normalized.
+func loadBlocklist(config wordListConfig) (map[string]struct{}, error) {
+	blocked := make(map[string]struct{})
+	if config.blocklistPath == "" {
+		return blocked, nil
+	}
+	words, err := utils.LoadWords(config.blocklistPath)
+	if err != nil {
+		return nil, err
+	}
+	language := config.languageOrDefault()
+	for _, word := range words {
+		blocked[language.Normalize(word)] = struct{}{}
+	}
+	return blocked, nil
+}
+
+// IsBlocked reports whether word is on the blocklist.
+func (wl *WordList) IsBlocked(word string) bool {
+	_, blocked := wl.blocked[wl.language.Normalize(word)]
+	return blocked
+}
+
+// Censor returns text with every character replaced by an asterisk if one of
+// its words is blocked, so a typed guess is masked as soon as it spells one.
+// Blocked words inside longer words are left alone. Other text is returned
+// unchanged.
+func (wl *WordList) Censor(text string) string {
+	words := strings.FieldsFunc(wl.language.Normalize(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		if _, blocked := wl.blocked[word]; blocked {
+			return strings.Repeat("*", utf8.RuneCountInString(text))
+		}
+	}
+	return text
+}
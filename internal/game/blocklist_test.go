@@ -0,0 +1,47 @@
+package game
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func TestWordListBlocklist(t *testing.T) {
+	dir := t.TempDir()
+	wordListPath := path.Join(dir, "words.txt")
+	blocklistPath := path.Join(dir, "blocklist.txt")
+	if err := os.WriteFile(wordListPath, []byte("apple\nbeach\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write word list: %v", err)
+	}
+	if err := os.WriteFile(blocklistPath, []byte("Beach\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write blocklist: %v", err)
+	}
+	wordList, err := NewWordList(wordListPath, WithBlocklist(blocklistPath))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	if answers := wordList.Answers(5); len(answers) != 1 || answers[0] != "apple" {
+		t.Errorf("Expected blocked words to never be answers, got %v", answers)
+	}
+	for i := 0; i < 10; i++ {
+		if word := wordList.RandomWord(); word != "apple" {
+			t.Errorf("Expected a random word to skip blocked words, got %s", word)
+		}
+	}
+	if !wordList.IsValidWord("beach") || !wordList.IsBlocked("BEACH") {
+		t.Error("Expected blocked words to stay valid guesses")
+	}
+	for text, expected := range map[string]string{
+		"beach":    "*****",
+		"BEACH":    "*****",
+		"a beach!": "********",
+		"xbEAchx":  "xbEAchx",
+		"beaches":  "beaches",
+		"bea":      "bea",
+		"apple":    "apple",
+	} {
+		if got := wordList.Censor(text); got != expected {
+			t.Errorf("Expected %s to be censored as %s, got %s", text, expected, got)
+		}
+	}
+}
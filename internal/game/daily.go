@@ -0,0 +1,88 @@
+package game
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// DailyEpoch is the date of Daily #1.
+var DailyEpoch = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// DailyNumber returns the puzzle number for the UTC calendar date of t,
+// counting from DailyEpoch as puzzle 1.
+func DailyNumber(t time.Time) int {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(DailyEpoch).Hours()/24) + 1
+}
+
+// DailyWord returns the answer of the given length for the UTC date of t,
+// together with its puzzle number. Answers are dealt from a shuffle of the
+// whole answer pool keyed by secret, so no answer repeats until every answer
+// has been used. It returns an empty word if the list has no answers of that
+// length or the date is before DailyEpoch.
+func (wl *WordList) DailyWord(t time.Time, secret string, length int) (string, int) {
+	number := DailyNumber(t)
+	answers := wl.dailyAnswers(length)
+	if len(answers) == 0 || number < 1 {
+		return "", number
+	}
+	return dailyAnswer(answers, secret, length, number-1), number
+}
+
+// DailyWords returns the daily answers for n boards played at once. The first
+// board gets the same answer as DailyWord; later boards use a secret derived
+// from the board index and skip ahead past answers dealt to earlier boards,
+// so no answer appears twice.
+func (wl *WordList) DailyWords(t time.Time, secret string, length, n int) ([]string, error) {
+	number := DailyNumber(t)
+	if number < 1 {
+		return nil, fmt.Errorf("no daily puzzle before %s", DailyEpoch.Format(time.DateOnly))
+	}
+	answers := wl.dailyAnswers(length)
+	if len(answers) < n {
+		return nil, fmt.Errorf("need %d answers of length %d for the daily puzzle, found %d", n, length, len(answers))
+	}
+	words := make([]string, n)
+	dealt := make(map[string]bool)
+	for i := range words {
+		boardSecret := secret
+		if i > 0 {
+			boardSecret = fmt.Sprintf("%s#%d", secret, i)
+		}
+		for index := number - 1; ; index++ {
+			if word := dailyAnswer(answers, boardSecret, length, index); !dealt[word] {
+				words[i] = word
+				dealt[word] = true
+				break
+			}
+		}
+	}
+	return words, nil
+}
+
+// dailyAnswers returns the answers of the given length in sorted order, so
+// the puzzle does not depend on the order of the word list file.
+func (wl *WordList) dailyAnswers(length int) []string {
+	answers := wl.Answers(length)
+	sort.Strings(answers)
+	return answers
+}
+
+// dailyAnswer deals the answer at index from the shuffles keyed by secret.
+func dailyAnswer(answers []string, secret string, length, index int) string {
+	cycle := index / len(answers)
+	order := rand.New(rand.NewSource(dailySeed(secret, length, cycle))).Perm(len(answers))
+	return answers[order[index%len(answers)]]
+}
+
+func dailySeed(secret string, length, cycle int) int64 {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d/%d", length, cycle)
+	return int64(binary.BigEndian.Uint64(mac.Sum(nil)))
+}
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+func TestDailyNumber(t *testing.T) {
+	if n := DailyNumber(DailyEpoch); n != 1 {
+		t.Errorf("Expected epoch to be Daily #1, got %d", n)
+	}
+	late := time.Date(2025, time.January, 2, 23, 59, 0, 0, time.FixedZone("UTC+8", 8*3600))
+	if n := DailyNumber(late); n != 2 {
+		t.Errorf("Expected Daily #2 for the second calendar day, got %d", n)
+	}
+	early := time.Date(2025, time.January, 2, 1, 0, 0, 0, time.FixedZone("UTC+8", 8*3600))
+	if n := DailyNumber(early); n != 1 {
+		t.Errorf("Expected Daily #1 while it is still January 1 in UTC, got %d", n)
+	}
+}
+
+func TestDailyWord_Stable(t *testing.T) {
+	wordList, err := NewWordList(path.Join(utils.Root, "assets", "words.txt"))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	date := time.Date(2025, time.March, 14, 9, 0, 0, 0, time.UTC)
+	word, number := wordList.DailyWord(date, "secret", 5)
+	again, _ := wordList.DailyWord(date.Add(10*time.Hour), "secret", 5)
+	if word == "" || word != again {
+		t.Errorf("Expected the same word all day, got %q and %q", word, again)
+	}
+	if number != DailyNumber(date) {
+		t.Errorf("Expected puzzle number %d, got %d", DailyNumber(date), number)
+	}
+}
+
+func TestDailyWord_NoRepeatsWithinCycle(t *testing.T) {
+	wordList, err := NewWordList(path.Join(utils.Root, "assets", "words.txt"))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	count := wordList.CountOfLength(5)
+	seen := make(map[string]bool)
+	for day := 0; day < count; day++ {
+		word, _ := wordList.DailyWord(DailyEpoch.AddDate(0, 0, day), "secret", 5)
+		if seen[word] {
+			t.Fatalf("Word %s repeated on day %d before the list was used up", word, day+1)
+		}
+		seen[word] = true
+	}
+}
+
+func TestDailyWords_Distinct(t *testing.T) {
+	wordListPath := path.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(wordListPath, []byte("apple\nbeach\ncrane\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write word list: %v", err)
+	}
+	wordList, err := NewWordList(wordListPath)
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	for day := 0; day < 30; day++ {
+		date := DailyEpoch.AddDate(0, 0, day)
+		words, err := wordList.DailyWords(date, "secret", 5, 3)
+		if err != nil {
+			t.Fatalf("DailyWords failed: %v", err)
+		}
+		if first, _ := wordList.DailyWord(date, "secret", 5); words[0] != first {
+			t.Errorf("Expected the first board to get %s, got %s", first, words[0])
+		}
+		if words[0] == words[1] || words[0] == words[2] || words[1] == words[2] {
+			t.Fatalf("Expected distinct answers on day %d, got %v", day+1, words)
+		}
+	}
+	if _, err := wordList.DailyWords(DailyEpoch, "secret", 5, 4); err == nil {
+		t.Error("Expected an error with fewer answers than boards")
+	}
+	if _, err := wordList.DailyWords(DailyEpoch.AddDate(0, 0, -1), "secret", 5, 1); err == nil {
+		t.Error("Expected an error before the epoch")
+	}
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ParseTier converts a tier name into a Tier.
+func ParseTier(name string) (Tier, error) {
+	if tier := Tier(strings.ToLower(name)); slices.Contains(Tiers, tier) {
+		return tier, nil
+	}
+	return "", fmt.Errorf("unknown difficulty tier: %s", name)
+}
+
+// EstimateDifficulty rates answers of the same length from letter frequency,
+// roughly in guesses needed. Answers made of common letters in common
+// positions rate easier; repeated letters and many answers that differ by a
+// single letter, such as the -ATCH words, rate harder.
+func EstimateDifficulty(answers []string) map[string]float64 {
+	ratings := make(map[string]float64, len(answers))
+	if len(answers) == 0 {
+		return ratings
+	}
+	length := Length(answers[0])
+	positional := make([]map[rune]int, length)
+	for i := range positional {
+		positional[i] = make(map[rune]int)
+	}
+	presence := make(map[rune]int)
+	// patterns counts the answers sharing every letter but one, keyed by the
+	// answer with that letter blanked out.
+	patterns := make(map[string]int)
+	// blanked returns letters with the letter at i replaced by a blank.
+	blanked := func(letters []rune, i int) string {
+		return string(letters[:i]) + "_" + string(letters[i+1:])
+	}
+	for _, answer := range answers {
+		letters := []rune(answer)
+		seen := make(map[rune]bool)
+		for i := 0; i < length && i < len(letters); i++ {
+			positional[i][letters[i]]++
+			if !seen[letters[i]] {
+				seen[letters[i]] = true
+				presence[letters[i]]++
+			}
+			patterns[blanked(letters, i)]++
+		}
+	}
+	total := float64(len(answers))
+	for _, answer := range answers {
+		letters := []rune(answer)
+		var commonness float64
+		neighbours := 0
+		seen := make(map[rune]bool)
+		for i := 0; i < length && i < len(letters); i++ {
+			commonness += float64(positional[i][letters[i]]+presence[letters[i]]) / (2 * total)
+			neighbours += patterns[blanked(letters, i)] - 1
+			seen[letters[i]] = true
+		}
+		commonness /= float64(length)
+		repeats := len(letters) - len(seen)
+		ratings[answer] = 3 + 2*(1-commonness) + 0.5*float64(repeats) + 0.5*math.Log2(1+float64(neighbours))
+	}
+	return ratings
+}
+
+// rateAnswers fills in the ratings of answers missing from difficulty.
+func rateAnswers(answers []string, difficulty map[string]float64) {
+	var estimated map[string]float64
+	for _, answer := range answers {
+		if _, rated := difficulty[answer]; rated {
+			continue
+		}
+		if estimated == nil {
+			estimated = EstimateDifficulty(answers)
+		}
+		difficulty[answer] = estimated[answer]
+	}
+}
+
+// assignTiers splits the answers of each length into thirds by difficulty.
+func assignTiers(byLength map[int][]string, difficulty map[string]float64) map[string]Tier {
+	tiers := make(map[string]Tier)
+	for _, answers := range byLength {
+		sorted := append([]string(nil), answers...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if difficulty[sorted[i]] != difficulty[sorted[j]] {
+				return difficulty[sorted[i]] < difficulty[sorted[j]]
+			}
+			return sorted[i] < sorted[j]
+		})
+		for i, answer := range sorted {
+			tiers[answer] = []Tier{EasyTier, NormalTier, HardTier}[i*3/len(sorted)]
+		}
+	}
+	return tiers
+}
+
+// Difficulty returns the rating of answer, higher being harder, or 0 if it
+// is not an answer.
+func (wl *WordList) Difficulty(answer string) float64 {
+	return wl.difficulty[wl.language.Normalize(answer)]
+}
+
+// Tier returns the difficulty tier of answer, or an empty Tier if it is not
+// an answer.
+func (wl *WordList) Tier(answer string) Tier {
+	return wl.tiers[wl.language.Normalize(answer)]
+}
+
+// InTier reports whether answer belongs to tier. Every answer belongs to
+// AnyTier.
+func (wl *WordList) InTier(answer string, tier Tier) bool {
+	return tier == AnyTier || wl.Tier(answer) == tier
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func TestParseTier(t *testing.T) {
+	for _, name := range []string{"any", "easy", "Normal", "HARD"} {
+		if _, err := ParseTier(name); err != nil {
+			t.Errorf("Expected %s to parse: %v", name, err)
+		}
+	}
+	if _, err := ParseTier("brutal"); err == nil {
+		t.Error("Expected an unknown tier to be rejected")
+	}
+}
+
+func TestEstimateDifficulty(t *testing.T) {
+	ratings := EstimateDifficulty([]string{"catch", "hatch", "latch", "match", "patch", "slate", "crane", "stone"})
+	if ratings["match"] <= ratings["slate"] {
+		t.Errorf("Expected a word with many one-letter neighbours to rate harder, got %v", ratings)
+	}
+}
+
+func TestWordListTiers(t *testing.T) {
+	wordListPath := path.Join(t.TempDir(), "rated.txt")
+	content := "apple 1 2\nbeach 1 3\nbread 1 4\nchair 1 5\ndance 1 6\neagle 1 7\n"
+	if err := os.WriteFile(wordListPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write word list: %v", err)
+	}
+	wordList, err := NewWordList(wordListPath)
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	expected := map[string]Tier{
+		"apple": EasyTier, "beach": EasyTier,
+		"bread": NormalTier, "chair": NormalTier,
+		"dance": HardTier, "eagle": HardTier,
+	}
+	for word, tier := range expected {
+		if got := wordList.Tier(word); got != tier {
+			t.Errorf("Expected %s to be %s, got %s", word, tier, got)
+		}
+		if !wordList.InTier(word, AnyTier) {
+			t.Errorf("Expected %s to be in any tier", word)
+		}
+	}
+	if wordList.Difficulty("dance") != 6 {
+		t.Errorf("Expected the stored rating to be kept, got %v", wordList.Difficulty("dance"))
+	}
+	if wordList.Tier("zzzzz") != "" {
+		t.Error("Expected words outside the answers to have no tier")
+	}
+}
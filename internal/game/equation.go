@@ -0,0 +1,232 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Operators allowed in equations.
+const Operators = "+-*/"
+
+// NewEquationPool generates every true equation of the given length with a
+// single operator, such as 12+35=47 or 96/8=12, to draw answers from.
+func NewEquationPool(length int, opts ...WordListOption) (*EquationPool, error) {
+	if !IsValidEquationLength(length) {
+		return nil, fmt.Errorf("equation length must be between %d and %d", MinEquationLength, MaxEquationLength)
+	}
+	var config wordListConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	pool := &EquationPool{byLength: map[int][]string{length: generateEquations(length)}}
+	if config.source != nil {
+		pool.rng = rand.New(config.source)
+	}
+	return pool, nil
+}
+
+// IsValidEquationLength reports whether length is a supported equation
+// length.
+func IsValidEquationLength(length int) bool {
+	return length >= MinEquationLength && length <= MaxEquationLength
+}
+
+// generateEquations lists the equations a<op>b=c of the given length in which
+// every number is a non-negative integer without leading zeros. Trivial
+// equations with a zero operand or multiplying or dividing by one are left
+// out.
+func generateEquations(length int) []string {
+	var equations []string
+	// The operator and the equals sign take two characters.
+	digits := length - 2
+	for aDigits := 1; aDigits < digits-1; aDigits++ {
+		for bDigits := 1; aDigits+bDigits < digits; bDigits++ {
+			cDigits := digits - aDigits - bDigits
+			aLow, aHigh := numbers(aDigits)
+			bLow, bHigh := numbers(bDigits)
+			for a := aLow; a <= aHigh; a++ {
+				for b := bLow; b <= bHigh; b++ {
+					for _, op := range Operators {
+						if a == 0 || b == 0 || b == 1 && (op == '*' || op == '/') {
+							continue
+						}
+						c, ok := apply(a, op, b)
+						if ok && len(strconv.Itoa(c)) == cDigits {
+							equations = append(equations, fmt.Sprintf("%d%c%d=%d", a, op, b, c))
+						}
+					}
+				}
+			}
+		}
+	}
+	return equations
+}
+
+// numbers returns the range of numbers written with exactly n digits.
+func numbers(n int) (low, high int) {
+	high = 1
+	for i := 0; i < n; i++ {
+		high *= 10
+	}
+	if n > 1 {
+		low = high / 10
+	}
+	return low, high - 1
+}
+
+// apply computes a op b, reporting false if the result is negative or not a
+// whole number.
+func apply(a int, op rune, b int) (int, bool) {
+	switch op {
+	case '+':
+		return a + b, true
+	case '-':
+		return a - b, a >= b
+	case '*':
+		return a * b, true
+	case '/':
+		if b == 0 || a%b != 0 {
+			return 0, false
+		}
+		return a / b, true
+	}
+	return 0, false
+}
+
+// float64 returns a random number in [0, 1) from the pool's source.
+func (p *EquationPool) float64() float64 {
+	if p.rng == nil {
+		return rand.Float64()
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rng.Float64()
+}
+
+// Answers returns the generated equations of the given length.
+func (p *EquationPool) Answers(length int) []string {
+	return append([]string(nil), p.byLength[length]...)
+}
+
+// Guesses returns the generated equations of the given length. Other true
+// equations are accepted too but are not listed.
+func (p *EquationPool) Guesses(length int) []string {
+	return p.Answers(length)
+}
+
+// CheckGuess returns an error if guess is not a true equation.
+func (p *EquationPool) CheckGuess(guess string) error {
+	return CheckEquation(guess)
+}
+
+// RandomWordsOfLength returns n distinct random equations of the given length.
+func (p *EquationPool) RandomWordsOfLength(length, n int) []string {
+	return pickWeighted(p.byLength[length], equalWeight, p.float64, n, nil)
+}
+
+// RandomWordsFrom is like RandomWordsOfLength but draws from r, avoiding
+// equations for which exclude returns true if it can.
+func (p *EquationPool) RandomWordsFrom(r *rand.Rand, length, n int, exclude func(string) bool) []string {
+	return pickWeighted(p.byLength[length], equalWeight, r.Float64, n, exclude)
+}
+
+// equalWeight gives every equation the same chance of being picked.
+func equalWeight(string) float64 {
+	return 1
+}
+
+// CheckEquation returns an error unless equation is an expression, an equals
+// sign and a number, and the expression evaluates to the number. Numbers may
+// not have leading zeros and operators may not be unary.
+func CheckEquation(equation string) error {
+	left, right, found := strings.Cut(equation, "=")
+	if !found || strings.Contains(right, "=") {
+		return errors.New("equation must contain exactly one =")
+	}
+	if !strings.ContainsAny(left, Operators) {
+		return errors.New("left side must contain an operator")
+	}
+	want, err := parseNumber(right)
+	if err != nil {
+		return fmt.Errorf("right side must be a number: %w", err)
+	}
+	got, err := EvaluateExpression(left)
+	if err != nil {
+		return err
+	}
+	if got.Cmp(new(big.Rat).SetInt64(want)) != 0 {
+		return fmt.Errorf("%s is %s, not %d", left, got.RatString(), want)
+	}
+	return nil
+}
+
+// EvaluateExpression computes an expression of non-negative integers joined
+// by + - * and /, with * and / binding tighter than + and -. Results are
+// exact, so 7/2*2 is 7.
+func EvaluateExpression(expr string) (*big.Rat, error) {
+	var terms []*big.Rat
+	var signs []rune
+	term, op := (*big.Rat)(nil), '+'
+	start := 0
+	for i := 0; i <= len(expr); i++ {
+		if i < len(expr) && !strings.ContainsRune(Operators, rune(expr[i])) {
+			continue
+		}
+		n, err := parseNumber(expr[start:i])
+		if err != nil {
+			return nil, err
+		}
+		value := new(big.Rat).SetInt64(n)
+		switch {
+		case term == nil:
+			term = value
+		case op == '*':
+			term.Mul(term, value)
+		case op == '/':
+			if n == 0 {
+				return nil, errors.New("division by zero")
+			}
+			term.Quo(term, value)
+		}
+		if i == len(expr) || expr[i] == '+' || expr[i] == '-' {
+			terms = append(terms, term)
+			term = nil
+		}
+		if i < len(expr) {
+			op = rune(expr[i])
+			if op == '+' || op == '-' {
+				signs = append(signs, op)
+			}
+		}
+		start = i + 1
+	}
+	result := new(big.Rat).Set(terms[0])
+	for i, sign := range signs {
+		if sign == '+' {
+			result.Add(result, terms[i+1])
+		} else {
+			result.Sub(result, terms[i+1])
+		}
+	}
+	return result, nil
+}
+
+// parseNumber parses a non-negative integer without a sign or leading zeros.
+func parseNumber(s string) (int64, error) {
+	if s == "" {
+		return 0, errors.New("missing number")
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("%s has a leading zero", s)
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
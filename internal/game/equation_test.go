@@ -0,0 +1,77 @@
+package game
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCheckEquation(t *testing.T) {
+	valid := []string{"12+35=47", "96/8=12", "2+3*4=14", "20-8/4=18", "7/2*2=7", "10-2-3=5"}
+	for _, equation := range valid {
+		if err := CheckEquation(equation); err != nil {
+			t.Errorf("CheckEquation(%q) failed: %v", equation, err)
+		}
+	}
+	invalid := []string{
+		"12+35=48", // false
+		"12+35",    // no equals sign
+		"1+2=3=3",  // two equals signs
+		"47=47",    // no operator
+		"012+3=15", // leading zero
+		"-5+9=4",   // unary minus
+		"5+*9=45",  // missing number
+		"9/0=0",    // division by zero
+		"12+ab=47", // letters
+		"3+4=07",   // leading zero on the right
+		"3+4=3+4",  // expression on the right
+	}
+	for _, equation := range invalid {
+		if err := CheckEquation(equation); err == nil {
+			t.Errorf("CheckEquation(%q) expected an error", equation)
+		}
+	}
+}
+
+func TestNewEquationPool(t *testing.T) {
+	if _, err := NewEquationPool(MaxEquationLength + 1); err == nil {
+		t.Error("expected unsupported length to be rejected")
+	}
+	pool, err := NewEquationPool(EquationLength, WithSource(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("NewEquationPool failed: %v", err)
+	}
+	answers := pool.Answers(EquationLength)
+	if len(answers) == 0 {
+		t.Fatal("expected generated equations")
+	}
+	for _, answer := range answers {
+		if len(answer) != EquationLength {
+			t.Fatalf("%q has the wrong length", answer)
+		}
+		if err := pool.CheckGuess(answer); err != nil {
+			t.Fatalf("generated equation %q is invalid: %v", answer, err)
+		}
+		if strings.Contains(answer, "*1=") || strings.HasPrefix(answer, "0") {
+			t.Errorf("generated trivial equation %q", answer)
+		}
+	}
+	picked := pool.RandomWordsOfLength(EquationLength, 2)
+	if len(picked) != 2 || picked[0] == picked[1] {
+		t.Errorf("expected two distinct equations, got %v", picked)
+	}
+}
+
+func TestGame_ScoresEquations(t *testing.T) {
+	g := NewGame("12+35=47", 6)
+	result, err := g.MakeGuess("10+37=47")
+	if err != nil {
+		t.Fatalf("MakeGuess failed: %v", err)
+	}
+	want := []MatchType{Hit, Miss, Hit, Hit, Miss, Hit, Hit, Hit}
+	for i, lr := range result {
+		if lr.MatchType != want[i] {
+			t.Errorf("character %d (%c): expected %v, got %v", i, lr.Letter, want[i], lr.MatchType)
+		}
+	}
+}
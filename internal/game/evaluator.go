@@ -0,0 +1,211 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ParseFeedback converts a feedback name into a Feedback.
+func ParseFeedback(name string) (Feedback, error) {
+	if feedback := Feedback(strings.ToLower(name)); slices.Contains(Feedbacks, feedback) {
+		return feedback, nil
+	}
+	return "", fmt.Errorf("unknown feedback: %s", name)
+}
+
+// Evaluator returns a new evaluator for f. Only LyingFeedback draws from
+// source. It returns nil for WordleFeedback, which games treat as Score.
+func (f Feedback) Evaluator(source rand.Source) Evaluator {
+	switch f {
+	case MastermindFeedback:
+		return EvaluatorFunc(ScoreMastermind)
+	case PeaksFeedback:
+		return EvaluatorFunc(ScorePeaks)
+	case LyingFeedback:
+		return NewLyingEvaluator(EvaluatorFunc(Score), source)
+	}
+	return nil
+}
+
+// Evaluate calls f(guess, answer).
+func (f EvaluatorFunc) Evaluate(guess, answer string) []LetterResult {
+	return f(guess, answer)
+}
+
+// ScoreMastermind scores guess like Score but only reveals how many letters
+// are hits and how many are present, as in Mastermind. The result holds one
+// peg per letter with no letter or position, sorted hits first.
+func ScoreMastermind(guess, answer string) []LetterResult {
+	result := Score(guess, answer)
+	for i := range result {
+		result[i].Letter = 0
+		result[i].Position = NoPosition
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].MatchType > result[j].MatchType
+	})
+	return result
+}
+
+// ScorePeaks marks each letter Hit if it is in the right position, and
+// otherwise Higher or Lower depending on where the answer's letter in that
+// position falls in the alphabet.
+func ScorePeaks(guess, answer string) []LetterResult {
+	guessRunes := []rune(guess)
+	answerRunes := []rune(answer)
+	result := make([]LetterResult, len(guessRunes))
+	for i, r := range guessRunes {
+		g, a := unicode.ToLower(r), unicode.ToLower(answerRunes[i])
+		result[i] = LetterResult{Letter: r, Position: i, MatchType: Hit}
+		if a > g {
+			result[i].MatchType = Higher
+		} else if a < g {
+			result[i].MatchType = Lower
+		}
+	}
+	return result
+}
+
+// NewLyingEvaluator falsifies one tile of every row scored by base, picking
+// the tile and the lie from source.
+func NewLyingEvaluator(base Evaluator, source rand.Source) *LyingEvaluator {
+	return &LyingEvaluator{base: base, rng: rand.New(source)}
+}
+
+// Evaluate scores guess with the base evaluator, then replaces the match type
+// of one random tile with a different one of the same kind.
+func (e *LyingEvaluator) Evaluate(guess, answer string) []LetterResult {
+	result := e.base.Evaluate(guess, answer)
+	if len(result) == 0 {
+		return result
+	}
+	i := e.rng.Intn(len(result))
+	choices := []MatchType{Miss, Present, Hit}
+	if t := result[i].MatchType; t == Higher || t == Lower {
+		choices = []MatchType{Hit, Higher, Lower}
+	}
+	lies := make([]MatchType, 0, len(choices)-1)
+	for _, t := range choices {
+		if t != result[i].MatchType {
+			lies = append(lies, t)
+		}
+	}
+	result[i].MatchType = lies[e.rng.Intn(len(lies))]
+	result[i].Lie = true
+	return result
+}
+
+// Conceal returns a copy of row with the Lie flags cleared, for sending
+// feedback to players before the game is over.
+func Conceal(row []LetterResult) []LetterResult {
+	concealed := make([]LetterResult, len(row))
+	for i, lr := range row {
+		lr.Lie = false
+		concealed[i] = lr
+	}
+	return concealed
+}
+
+// Lies lists the falsified tiles in history, together with the match type
+// Score gives them against answer.
+func Lies(history [][]LetterResult, answer string) []Lie {
+	var lies []Lie
+	for i, row := range history {
+		truth := Score(Word(row), answer)
+		for j, lr := range row {
+			if lr.Lie {
+				lies = append(lies, Lie{
+					Attempt:  i,
+					Position: lr.Position,
+					Letter:   lr.Letter,
+					Shown:    lr.MatchType,
+					Actual:   truth[j].MatchType,
+				})
+			}
+		}
+	}
+	return lies
+}
+
+// String describes the lie, for example "letter 3 (a) was shown as hit but
+// was present".
+func (l Lie) String() string {
+	return fmt.Sprintf("letter %d (%c) was shown as %s but was %s", l.Position+1, l.Letter, l.Shown, l.Actual)
+}
+
+// CountLies returns how many tiles of row disagree with the feedback its
+// guess would get against answer.
+func CountLies(row []LetterResult, answer string) int {
+	lies := 0
+	for i, lr := range Score(Word(row), answer) {
+		if lr.MatchType != row[i].MatchType {
+			lies++
+		}
+	}
+	return lies
+}
+
+// Word returns the guess a row of tiles was scored for.
+func Word(row []LetterResult) string {
+	guess := make([]rune, len(row))
+	for i, lr := range row {
+		guess[i] = lr.Letter
+	}
+	return string(guess)
+}
+
+// Tally counts the hits and present letters in a row.
+func Tally(row []LetterResult) (hits, presents int) {
+	for _, lr := range row {
+		switch lr.MatchType {
+		case Hit:
+			hits++
+		case Present:
+			presents++
+		}
+	}
+	return hits, presents
+}
+
+// IsPegs reports whether row holds Mastermind pegs rather than one tile per
+// letter.
+func IsPegs(row []LetterResult) bool {
+	return len(row) > 0 && row[0].Position == NoPosition
+}
+
+// String renders the tile as a four column cell: [x] for Hit, (x) for
+// Present, x followed by an arrow for Higher and Lower, and x for Miss.
+func (lr LetterResult) String() string {
+	letter := string(lr.Letter)
+	switch lr.MatchType {
+	case Hit:
+		return "[" + letter + "] "
+	case Present:
+		return "(" + letter + ") "
+	case Higher:
+		return " " + letter + "↑ "
+	case Lower:
+		return " " + letter + "↓ "
+	}
+	return " " + letter + "  "
+}
+
+// FormatRow renders the feedback for guess as a row of cells. Pegs are shown
+// after the guess as the number of hits in brackets and present letters in
+// parentheses. Rows are 4 columns per letter wide.
+func FormatRow(guess string, row []LetterResult) string {
+	if IsPegs(row) {
+		hits, presents := Tally(row)
+		cell := fmt.Sprintf("%s [%d] (%d)", guess, hits, presents)
+		return cell + strings.Repeat(" ", max(0, 4*len(row)-len(cell)))
+	}
+	var b strings.Builder
+	for _, lr := range row {
+		b.WriteString(lr.String())
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestScoreMastermind(t *testing.T) {
+	result := ScoreMastermind("plead", "apple")
+	hits, presents := Tally(result)
+	if hits != 0 || presents != 4 {
+		t.Errorf("expected 0 hits and 4 present, got %d and %d", hits, presents)
+	}
+	if !IsPegs(result) {
+		t.Errorf("expected pegs, got %v", result)
+	}
+	for _, lr := range result {
+		if lr.Letter != 0 {
+			t.Errorf("peg reveals letter %c", lr.Letter)
+		}
+	}
+	result = ScoreMastermind("apply", "apple")
+	want := []MatchType{Hit, Hit, Hit, Hit, Miss}
+	for i, lr := range result {
+		if lr.MatchType != want[i] {
+			t.Errorf("peg %d: expected %d, got %d", i, want[i], lr.MatchType)
+		}
+	}
+}
+
+func TestScorePeaks(t *testing.T) {
+	result := ScorePeaks("mango", "apple")
+	want := []MatchType{Lower, Higher, Higher, Higher, Lower}
+	for i, lr := range result {
+		if lr.MatchType != want[i] {
+			t.Errorf("letter %d: expected %d, got %d", i, want[i], lr.MatchType)
+		}
+	}
+	g := NewGame("apple", 6, WithEvaluator(EvaluatorFunc(ScorePeaks)))
+	if _, err := g.MakeGuess("apple"); err != nil {
+		t.Fatalf("MakeGuess failed: %v", err)
+	}
+	if g.Status() != Won {
+		t.Errorf("expected game to be won, got %v", g.Status())
+	}
+}
+
+func TestLyingEvaluator(t *testing.T) {
+	e := NewLyingEvaluator(EvaluatorFunc(Score), rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		truth := Score("plead", "apple")
+		result := e.Evaluate("plead", "apple")
+		lies := 0
+		for j, lr := range result {
+			if lr.Lie {
+				lies++
+				if lr.MatchType == truth[j].MatchType {
+					t.Errorf("tile %d is marked as a lie but tells the truth", j)
+				}
+			} else if lr.MatchType != truth[j].MatchType {
+				t.Errorf("tile %d lies without being marked", j)
+			}
+		}
+		if lies != 1 {
+			t.Errorf("expected exactly one lie, got %d", lies)
+		}
+	}
+	for _, lr := range Conceal(e.Evaluate("plead", "apple")) {
+		if lr.Lie {
+			t.Error("Conceal left a lie flag set")
+		}
+	}
+}
+
+func TestParseFeedback(t *testing.T) {
+	for _, name := range []string{"wordle", "Mastermind", "peaks", "LYING"} {
+		if _, err := ParseFeedback(name); err != nil {
+			t.Errorf("ParseFeedback(%q) failed: %v", name, err)
+		}
+	}
+	if _, err := ParseFeedback("colors"); err == nil {
+		t.Error("expected unknown feedback to be rejected")
+	}
+	if WordleFeedback.Evaluator(rand.NewSource(1)) != nil {
+		t.Error("expected wordle feedback to use Score")
+	}
+}
+
+func TestMultiGame_Lies(t *testing.T) {
+	e := NewLyingEvaluator(EvaluatorFunc(Score), rand.NewSource(3))
+	m := NewMultiGame(NewGame("apple", 6), NewGame("lemon", 6, WithEvaluator(e)))
+	for _, guess := range []string{"plead", "mango"} {
+		if _, err := m.MakeGuess(guess); err != nil {
+			t.Fatalf("MakeGuess(%q) failed: %v", guess, err)
+		}
+	}
+	lies := m.Lies()
+	if len(lies) != 2 {
+		t.Fatalf("expected one lie per row on board 2, got %v", lies)
+	}
+	for i, lie := range lies {
+		if lie.Board != 1 || lie.Attempt != i {
+			t.Errorf("unexpected lie %+v", lie)
+		}
+		row := m.Boards[1].History()[lie.Attempt]
+		truth := Score(Word(row), "lemon")[lie.Position]
+		if lie.Actual != truth.MatchType || lie.Shown == lie.Actual {
+			t.Errorf("lie %+v does not match the true feedback %v", lie, truth.MatchType)
+		}
+	}
+}
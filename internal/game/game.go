@@ -2,30 +2,112 @@ package game
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
 	"unicode"
 )
 
-func NewGame(answer string, maxGuesses int) *Game {
-	return &Game{
+func NewGame(answer string, maxGuesses int, opts ...Option) *Game {
+	g := &Game{
 		Answer:     answer,
 		MaxGuesses: maxGuesses,
 		Attempts:   make([][]LetterResult, 0, maxGuesses),
 		State:      InProgress,
 	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithHardMode requires every guess to reuse the hints revealed so far.
+func WithHardMode(enabled bool) Option {
+	return func(g *Game) {
+		g.HardMode = enabled
+	}
+}
+
+// WithEvaluator scores guesses with e instead of Score.
+func WithEvaluator(e Evaluator) Option {
+	return func(g *Game) {
+		g.Evaluator = e
+	}
+}
+
+// ParseMode converts a mode name into a Mode.
+func ParseMode(name string) (Mode, error) {
+	if mode := Mode(strings.ToLower(name)); slices.Contains(Modes, mode) {
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown game mode: %s", name)
 }
 
 func (g *Game) MakeGuess(guess string) ([]LetterResult, error) {
+	if err := g.Validate(guess); err != nil {
+		return nil, err
+	}
+	result := g.evaluate(guess, g.Answer)
+	g.record(guess, result)
+	return result, nil
+}
+
+func (g *Game) Status() GameState {
+	return g.State
+}
+
+func (g *Game) History() [][]LetterResult {
+	return g.Attempts
+}
+
+func (g *Game) Solution() string {
+	return g.Answer
+}
+
+// Validate rejects guesses that cannot be played in the current state.
+func (g *Game) Validate(guess string) error {
 	if g.State != InProgress {
-		return nil, errors.New("game is not in progress")
+		return errors.New("game is not in progress")
+	}
+	if Length(guess) != Length(g.Answer) {
+		return errors.New("invalid guess length")
+	}
+	if g.HardMode {
+		if err := g.checkHardMode(guess); err != nil {
+			return err
+		}
 	}
-	if len(guess) != len(g.Answer) {
-		return nil, errors.New("invalid guess length")
+	return nil
+}
+
+// evaluate scores guess against answer with the game's evaluator.
+func (g *Game) evaluate(guess, answer string) []LetterResult {
+	if g.Evaluator == nil {
+		return Score(guess, answer)
+	}
+	return g.Evaluator.Evaluate(guess, answer)
+}
+
+// record appends result to the attempts and advances the game state.
+func (g *Game) record(guess string, result []LetterResult) {
+	g.Attempts = append(g.Attempts, result)
+	if strings.EqualFold(guess, g.Answer) {
+		g.State = Won
+	} else if len(g.Attempts) >= g.MaxGuesses {
+		g.State = Lost
+	} else {
+		g.State = InProgress
 	}
-	result := make([]LetterResult, len(guess))
-	answerRunes := []rune(g.Answer)
+}
+
+// Score compares guess against answer using the two-pass Wordle rules: exact
+// matches are marked first, then the remaining letters are matched left to
+// right so that each answer letter is counted at most once.
+func Score(guess, answer string) []LetterResult {
+	answerRunes := []rune(answer)
 	guessRunes := []rune(guess)
-	used := make([]bool, len(g.Answer))
+	result := make([]LetterResult, len(guessRunes))
+	used := make([]bool, len(answerRunes))
 	// First pass: check for hits
 	for i, r := range guessRunes {
 		if unicode.ToLower(r) == unicode.ToLower(answerRunes[i]) {
@@ -52,13 +134,56 @@ func (g *Game) MakeGuess(guess string) ([]LetterResult, error) {
 			result[i] = LetterResult{Letter: r, Position: i, MatchType: Miss}
 		}
 	}
-	g.Attempts = append(g.Attempts, result)
-	if strings.EqualFold(guess, g.Answer) {
-		g.State = Won
-	} else if len(g.Attempts) >= g.MaxGuesses {
-		g.State = Lost
-	} else {
-		g.State = InProgress
+	return result
+}
+
+// Pattern encodes a feedback row as a number in base matchTypes so rows can
+// be compared and used as map keys.
+func Pattern(row []LetterResult) int {
+	pattern := 0
+	for _, lr := range row {
+		pattern = pattern*matchTypes + int(lr.MatchType)
 	}
-	return result, nil
+	return pattern
+}
+
+// checkHardMode verifies that guess keeps every Hit in place and reuses every
+// Present letter revealed by previous attempts. A letter revealed more than
+// once in a single attempt must appear at least that many times.
+func (g *Game) checkHardMode(guess string) error {
+	guessRunes := []rune(strings.ToLower(guess))
+	counts := make(map[rune]int)
+	for _, r := range guessRunes {
+		counts[r]++
+	}
+	required := make(map[rune]int)
+	for _, attempt := range g.Attempts {
+		revealed := make(map[rune]int)
+		for _, lr := range attempt {
+			letter := unicode.ToLower(lr.Letter)
+			if lr.Position == NoPosition {
+				continue
+			}
+			if lr.MatchType == Hit && guessRunes[lr.Position] != letter {
+				return &HardModeError{Rule: KeepHit, Letter: letter, Position: lr.Position}
+			}
+			if lr.MatchType == Hit || lr.MatchType == Present {
+				revealed[letter]++
+			}
+		}
+		for letter, n := range revealed {
+			if n > required[letter] {
+				required[letter] = n
+			}
+		}
+	}
+	for _, attempt := range g.Attempts {
+		for _, lr := range attempt {
+			letter := unicode.ToLower(lr.Letter)
+			if lr.MatchType == Present && counts[letter] < required[letter] {
+				return &HardModeError{Rule: UsePresent, Letter: letter, Position: lr.Position}
+			}
+		}
+	}
+	return nil
 }
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -197,3 +198,48 @@ func TestMakeGuess_MakeGuessAfterWin(t *testing.T) {
 		t.Errorf("Expected nil result after game won, got %v", result)
 	}
 }
+
+func TestMakeGuess_HardModeKeepHit(t *testing.T) {
+	game := NewGame("apple", 6, WithHardMode(true))
+	game.MakeGuess("grape")
+	// "grape" vs "apple" reveals a Hit for e at position 5
+	result, err := game.MakeGuess("plead")
+	var hardModeErr *HardModeError
+	if !errors.As(err, &hardModeErr) {
+		t.Fatalf("Expected HardModeError, got %v", err)
+	}
+	if hardModeErr.Rule != KeepHit || hardModeErr.Letter != 'e' || hardModeErr.Position != 4 {
+		t.Errorf("Expected keep_hit violation for e at position 4, got %v", hardModeErr)
+	}
+	if result != nil {
+		t.Errorf("Expected nil result for rejected guess, got %v", result)
+	}
+	if len(game.Attempts) != 1 {
+		t.Errorf("Expected rejected guess not to be recorded, got %d attempts", len(game.Attempts))
+	}
+}
+
+func TestMakeGuess_HardModeUsePresent(t *testing.T) {
+	game := NewGame("apple", 6, WithHardMode(true))
+	game.MakeGuess("grape")
+	// a and p are Present, so a guess without p is rejected
+	_, err := game.MakeGuess("abase")
+	var hardModeErr *HardModeError
+	if !errors.As(err, &hardModeErr) {
+		t.Fatalf("Expected HardModeError, got %v", err)
+	}
+	if hardModeErr.Rule != UsePresent || hardModeErr.Letter != 'p' {
+		t.Errorf("Expected use_present violation for p, got %v", hardModeErr)
+	}
+	if _, err := game.MakeGuess("ample"); err != nil {
+		t.Errorf("Expected guess using all hints to be accepted, got %v", err)
+	}
+}
+
+func TestMakeGuess_HardModeDisabled(t *testing.T) {
+	game := NewGame("apple", 6)
+	game.MakeGuess("grape")
+	if _, err := game.MakeGuess("magic"); err != nil {
+		t.Errorf("Expected guess to be accepted without hard mode, got %v", err)
+	}
+}
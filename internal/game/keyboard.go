@@ -0,0 +1,131 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// KeyboardLayout lists the QWERTY rows used to render a Keyboard.
+var KeyboardLayout = []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// EquationLayout lists the keys used to render a Keyboard in Nerdle.
+var EquationLayout = []string{"1234567890", "+-*/="}
+
+// NewKeyboard folds attempts into the best-known state of every letter.
+func NewKeyboard(attempts [][]LetterResult) Keyboard {
+	k := Keyboard{}
+	for _, attempt := range attempts {
+		k.Update(attempt)
+	}
+	return k
+}
+
+// Keyboard returns the state of every letter guessed so far.
+func (g *Game) Keyboard() Keyboard {
+	return NewKeyboard(g.Attempts)
+}
+
+// Keyboards returns the keyboard of each board. Boards share guesses but not
+// feedback, so every board has its own keyboard.
+func (m *MultiGame) Keyboards() []Keyboard {
+	keyboards := make([]Keyboard, len(m.Boards))
+	for i, board := range m.Boards {
+		keyboards[i] = NewKeyboard(board.History())
+	}
+	return keyboards
+}
+
+// Update raises the state of each letter in result. Hit beats Present, which
+// beats Miss. Pegs and Peaks arrows say nothing about a letter on its own, so
+// they leave the keyboard unchanged.
+func (k Keyboard) Update(result []LetterResult) {
+	for _, lr := range result {
+		if lr.Position == NoPosition || lr.MatchType == Higher || lr.MatchType == Lower {
+			continue
+		}
+		if state := keyState(lr.MatchType); state > k[lr.Letter] {
+			k[lr.Letter] = state
+		}
+	}
+}
+
+// State returns the best-known state of letter.
+func (k Keyboard) State(letter rune) KeyState {
+	return k[letter]
+}
+
+// MarshalJSON encodes the keyboard as an object keyed by letter, such as
+// {"a":3}, rather than by code point.
+func (k Keyboard) MarshalJSON() ([]byte, error) {
+	letters := make(map[string]KeyState, len(k))
+	for letter, state := range k {
+		letters[string(letter)] = state
+	}
+	return json.Marshal(letters)
+}
+
+// UnmarshalJSON decodes a keyboard encoded by MarshalJSON.
+func (k *Keyboard) UnmarshalJSON(data []byte) error {
+	var letters map[string]KeyState
+	if err := json.Unmarshal(data, &letters); err != nil {
+		return err
+	}
+	if letters == nil {
+		*k = nil
+		return nil
+	}
+	*k = make(Keyboard, len(letters))
+	for key, state := range letters {
+		letter, size := utf8.DecodeRuneInString(key)
+		if letter == utf8.RuneError || size != len(key) {
+			return fmt.Errorf("invalid keyboard letter %q", key)
+		}
+		(*k)[letter] = state
+	}
+	return nil
+}
+
+// Rows renders the keyboard as QWERTY rows, marking letters [x] for Hit,
+// (x) for Present and - for Miss. Every row has the same width.
+func (k Keyboard) Rows() []string {
+	return k.RowsOf(KeyboardLayout)
+}
+
+// RowsOf is like Rows but renders the keys in layout.
+func (k Keyboard) RowsOf(layout []string) []string {
+	width := 0
+	for _, row := range layout {
+		width = max(width, 4*Length(row))
+	}
+	rows := make([]string, len(layout))
+	for i, row := range layout {
+		var b strings.Builder
+		b.WriteString(strings.Repeat(" ", 2*i))
+		for _, letter := range row {
+			switch k[letter] {
+			case KeyHit:
+				b.WriteString("[" + string(letter) + "] ")
+			case KeyPresent:
+				b.WriteString("(" + string(letter) + ") ")
+			case KeyMiss:
+				b.WriteString(" -  ")
+			default:
+				b.WriteString(" " + string(letter) + "  ")
+			}
+		}
+		rows[i] = b.String() + strings.Repeat(" ", width-Length(b.String()))
+	}
+	return rows
+}
+
+func keyState(matchType MatchType) KeyState {
+	switch matchType {
+	case Hit:
+		return KeyHit
+	case Present:
+		return KeyPresent
+	}
+	return KeyMiss
+}
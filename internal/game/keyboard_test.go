@@ -0,0 +1,72 @@
+package game
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeyboard_KeepsBestState(t *testing.T) {
+	g := NewGame("apple", 6)
+	for _, guess := range []string{"plead", "apply"} {
+		if _, err := g.MakeGuess(guess); err != nil {
+			t.Fatalf("MakeGuess(%q) failed: %v", guess, err)
+		}
+	}
+	k := g.Keyboard()
+	tests := map[rune]KeyState{
+		'a': KeyHit,
+		'p': KeyHit,
+		'l': KeyHit,
+		'e': KeyPresent,
+		'd': KeyMiss,
+		'y': KeyMiss,
+		'z': Unused,
+	}
+	for letter, want := range tests {
+		if got := k.State(letter); got != want {
+			t.Errorf("State(%c) = %d, want %d", letter, got, want)
+		}
+	}
+}
+
+func TestKeyboard_Rows(t *testing.T) {
+	k := NewKeyboard([][]LetterResult{Score("qazwx", "quack")})
+	rows := k.Rows()
+	if len(rows) != len(KeyboardLayout) {
+		t.Fatalf("expected %d rows, got %d", len(KeyboardLayout), len(rows))
+	}
+	if !strings.HasPrefix(rows[0], "[q]  -   e ") {
+		t.Errorf("unexpected first row %q", rows[0])
+	}
+	if !strings.Contains(rows[1], "(a)") || !strings.HasPrefix(rows[2], "     -   -   c ") {
+		t.Errorf("unexpected rows %q", rows)
+	}
+	for _, row := range rows {
+		if len(row) != len(rows[0]) {
+			t.Errorf("row %q is not as wide as %q", row, rows[0])
+		}
+	}
+}
+
+func TestKeyboard_JSONRoundTrip(t *testing.T) {
+	k := Keyboard{'a': KeyHit, 'e': KeyPresent, 'ñ': KeyMiss}
+	data, err := json.Marshal(k)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if want := `{"a":3,"e":2,"ñ":1}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+	var decoded Keyboard
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, k) {
+		t.Errorf("Expected %v after the round trip, got %v", k, decoded)
+	}
+	if err := json.Unmarshal([]byte(`{"ab":3}`), &decoded); err == nil {
+		t.Error("Expected an error for a key that is not a single letter")
+	}
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"unicode"
+)
+
+// NewKnowledge folds attempts into the constraints they place on an answer of
+// the given length. Duplicate letters follow the same rules as Score: a letter
+// marked Miss alongside a Hit or Present of the same letter caps its count.
+func NewKnowledge(length int, attempts [][]LetterResult) *Knowledge {
+	k := &Knowledge{
+		Length:   length,
+		Fixed:    make([]rune, length),
+		Excluded: make([]map[rune]bool, length),
+		MinCount: make(map[rune]int),
+		MaxCount: make(map[rune]int),
+	}
+	for i := range k.Excluded {
+		k.Excluded[i] = make(map[rune]bool)
+	}
+	for _, attempt := range attempts {
+		found := make(map[rune]int)
+		missed := make(map[rune]bool)
+		for _, lr := range attempt {
+			if lr.Position < 0 || lr.Position >= length {
+				continue
+			}
+			letter := unicode.ToLower(lr.Letter)
+			switch lr.MatchType {
+			case Hit:
+				k.Fixed[lr.Position] = letter
+				found[letter]++
+			case Present:
+				k.Excluded[lr.Position][letter] = true
+				found[letter]++
+			case Higher, Lower:
+				k.Excluded[lr.Position][letter] = true
+			case Miss:
+				k.Excluded[lr.Position][letter] = true
+				missed[letter] = true
+			}
+		}
+		for letter, n := range found {
+			if n > k.MinCount[letter] {
+				k.MinCount[letter] = n
+			}
+		}
+		for letter := range missed {
+			if max, ok := k.MaxCount[letter]; !ok || found[letter] < max {
+				k.MaxCount[letter] = found[letter]
+			}
+		}
+	}
+	return k
+}
+
+// Knowledge returns the constraints revealed by the game's attempts so far.
+func (g *Game) Knowledge() *Knowledge {
+	return NewKnowledge(len([]rune(g.Answer)), g.Attempts)
+}
+
+// IsConsistent reports whether word could still be the answer.
+func (k *Knowledge) IsConsistent(word string) bool {
+	runes := []rune(word)
+	if len(runes) != k.Length {
+		return false
+	}
+	counts := make(map[rune]int)
+	for i, r := range runes {
+		r = unicode.ToLower(r)
+		if k.Fixed[i] != 0 && k.Fixed[i] != r {
+			return false
+		}
+		if k.Excluded[i][r] {
+			return false
+		}
+		counts[r]++
+	}
+	for letter, min := range k.MinCount {
+		if counts[letter] < min {
+			return false
+		}
+	}
+	for letter, max := range k.MaxCount {
+		if counts[letter] > max {
+			return false
+		}
+	}
+	return true
+}
+
+// Candidates returns the answers from answers that are still possible.
+func (k *Knowledge) Candidates(answers AnswerProvider) []string {
+	var candidates []string
+	for _, word := range answers.Answers(k.Length) {
+		if k.IsConsistent(word) {
+			candidates = append(candidates, word)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"path"
+	"testing"
+
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+func sameFeedback(a, b []LetterResult) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].MatchType != b[i].MatchType {
+			return false
+		}
+	}
+	return true
+}
+
+func TestKnowledge_DuplicateLetterAfterHit(t *testing.T) {
+	game := NewGame("smile", 6)
+	game.MakeGuess("skill")
+	k := game.Knowledge()
+	// s and i and the first l are fixed, the second l caps l at one
+	if k.Fixed[0] != 's' || k.Fixed[2] != 'i' || k.Fixed[3] != 'l' {
+		t.Errorf("Expected s, i and l to be fixed, got %q", string(k.Fixed))
+	}
+	if k.MinCount['l'] != 1 || k.MaxCount['l'] != 1 {
+		t.Errorf("Expected exactly one l, got min %d max %d", k.MinCount['l'], k.MaxCount['l'])
+	}
+	if k.MaxCount['k'] != 0 {
+		t.Errorf("Expected k to be excluded, got max %d", k.MaxCount['k'])
+	}
+	if !k.IsConsistent("smile") {
+		t.Errorf("Expected smile to be consistent")
+	}
+	if k.IsConsistent("skill") || k.IsConsistent("spill") {
+		t.Errorf("Expected words with two l's to be inconsistent")
+	}
+}
+
+func TestKnowledge_DuplicateLetterAfterPresent(t *testing.T) {
+	game := NewGame("smile", 6)
+	game.MakeGuess("alley")
+	k := game.Knowledge()
+	if k.MinCount['l'] != 1 || k.MaxCount['l'] != 1 {
+		t.Errorf("Expected exactly one l, got min %d max %d", k.MinCount['l'], k.MaxCount['l'])
+	}
+	if !k.Excluded[1]['l'] || !k.Excluded[2]['l'] {
+		t.Errorf("Expected l to be excluded from positions 2 and 3")
+	}
+	if !k.IsConsistent("smile") {
+		t.Errorf("Expected smile to be consistent")
+	}
+}
+
+func TestKnowledge_MatchesScore(t *testing.T) {
+	wordList, err := NewWordList(path.Join(utils.Root, "assets", "words.txt"))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	words := wordList.Answers(5)
+	for _, answer := range words {
+		for _, guess := range words {
+			feedback := Score(guess, answer)
+			k := NewKnowledge(5, [][]LetterResult{feedback})
+			for _, word := range words {
+				want := sameFeedback(Score(guess, word), feedback)
+				if got := k.IsConsistent(word); got != want {
+					t.Fatalf("guess %s, answer %s: IsConsistent(%s) = %v, want %v", guess, answer, word, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestKnowledge_Candidates(t *testing.T) {
+	wordList, err := NewWordList(path.Join(utils.Root, "assets", "words.txt"))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	game := NewGame("smile", 6)
+	game.MakeGuess("skill")
+	candidates := game.Knowledge().Candidates(wordList)
+	if len(candidates) != 1 || candidates[0] != "smile" {
+		t.Errorf("Expected only smile to remain, got %v", candidates)
+	}
+}
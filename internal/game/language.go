@@ -0,0 +1,134 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tomlaws/wordle/assets"
+)
+
+// Built-in language packs.
+var (
+	// English folds every accent, so "café" is played as "cafe".
+	English = newLanguage(&Language{
+		Code:               "en",
+		Name:               "English",
+		Alphabet:           "abcdefghijklmnopqrstuvwxyz",
+		Layout:             KeyboardLayout,
+		Substitutions:      []string{"ß", "ss", "æ", "ae", "œ", "oe"},
+		Fold:               accentFold,
+		WordListPath:       "assets/words.txt",
+		AllowedGuessesPath: "assets/allowed.txt",
+		words:              assets.Words,
+		allowed:            assets.Allowed,
+	})
+	// Spanish folds accented vowels but keeps ñ as a letter.
+	Spanish = newLanguage(&Language{
+		Code:         "es",
+		Name:         "Español",
+		Alphabet:     "abcdefghijklmnñopqrstuvwxyz",
+		Layout:       []string{"qwertyuiop", "asdfghjklñ", "zxcvbnm"},
+		Fold:         map[rune]rune{'á': 'a', 'é': 'e', 'í': 'i', 'ó': 'o', 'ú': 'u', 'ü': 'u'},
+		WordListPath: "assets/es/words.txt",
+		words:        assets.SpanishWords,
+	})
+	// German keeps umlauts as letters and spells ß as ss.
+	German = newLanguage(&Language{
+		Code:          "de",
+		Name:          "Deutsch",
+		Alphabet:      "abcdefghijklmnopqrstuvwxyzäöü",
+		Layout:        []string{"qwertzuiopü", "asdfghjklöä", "yxcvbnm"},
+		Substitutions: []string{"ß", "ss"},
+		WordListPath:  "assets/de/words.txt",
+		words:         assets.GermanWords,
+	})
+)
+
+// Languages lists the built-in language packs.
+var Languages = []*Language{English, Spanish, German}
+
+// combining maps a letter followed by a combining mark to the precomposed
+// letter, so "n" followed by U+0303 is read as "ñ".
+var combining = map[[2]rune]rune{
+	{'a', '\u0300'}: 'à', {'e', '\u0300'}: 'è', {'i', '\u0300'}: 'ì', {'o', '\u0300'}: 'ò', {'u', '\u0300'}: 'ù',
+	{'a', '\u0301'}: 'á', {'e', '\u0301'}: 'é', {'i', '\u0301'}: 'í', {'o', '\u0301'}: 'ó', {'u', '\u0301'}: 'ú',
+	{'y', '\u0301'}: 'ý', {'c', '\u0301'}: 'ć', {'n', '\u0301'}: 'ń', {'s', '\u0301'}: 'ś', {'z', '\u0301'}: 'ź',
+	{'a', '\u0302'}: 'â', {'e', '\u0302'}: 'ê', {'i', '\u0302'}: 'î', {'o', '\u0302'}: 'ô', {'u', '\u0302'}: 'û',
+	{'a', '\u0303'}: 'ã', {'n', '\u0303'}: 'ñ', {'o', '\u0303'}: 'õ',
+	{'a', '\u0308'}: 'ä', {'e', '\u0308'}: 'ë', {'i', '\u0308'}: 'ï', {'o', '\u0308'}: 'ö', {'u', '\u0308'}: 'ü',
+	{'y', '\u0308'}: 'ÿ',
+	{'a', '\u030a'}: 'å',
+	{'c', '\u0327'}: 'ç',
+	{'c', '\u030c'}: 'č', {'e', '\u030c'}: 'ě', {'n', '\u030c'}: 'ň', {'r', '\u030c'}: 'ř', {'s', '\u030c'}: 'š',
+	{'z', '\u030c'}: 'ž',
+}
+
+// accentFold maps every precomposed letter in combining to its base letter.
+var accentFold = func() map[rune]rune {
+	fold := make(map[rune]rune, len(combining))
+	for pair, composed := range combining {
+		fold[composed] = pair[0]
+	}
+	return fold
+}()
+
+func newLanguage(l *Language) *Language {
+	l.replacer = strings.NewReplacer(l.Substitutions...)
+	return l
+}
+
+// LookupLanguage returns the built-in language pack with the given code.
+func LookupLanguage(code string) (*Language, error) {
+	for _, language := range Languages {
+		if strings.EqualFold(language.Code, code) {
+			return language, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown language: %s", code)
+}
+
+// Normalize lowercases word, composes combining marks with the letter
+// before them, applies the substitutions and folds accents, so words that
+// players consider equal compare equal. Combining marks that do not compose
+// are dropped, so every rune of the result is a whole letter.
+func (l *Language) Normalize(word string) string {
+	runes := make([]rune, 0, len(word))
+	for _, r := range strings.ToLower(word) {
+		if unicode.Is(unicode.Mn, r) {
+			if n := len(runes); n > 0 {
+				if composed, ok := combining[[2]rune{runes[n-1], r}]; ok {
+					runes[n-1] = composed
+				}
+			}
+			continue
+		}
+		runes = append(runes, r)
+	}
+	substituted := []rune(l.replacer.Replace(string(runes)))
+	for i, r := range substituted {
+		if folded, ok := l.Fold[r]; ok {
+			substituted[i] = folded
+		}
+	}
+	return string(substituted)
+}
+
+// InAlphabet reports whether every letter of the normalized word belongs to
+// the language's alphabet.
+func (l *Language) InAlphabet(word string) bool {
+	for _, r := range word {
+		if !strings.ContainsRune(l.Alphabet, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Length returns the number of letters in word, counting runes rather than
+// bytes so "ñandú" has 5 letters. Words should be normalized first so each
+// letter is a single rune.
+func Length(word string) int {
+	return utf8.RuneCountInString(word)
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"path"
+	"testing"
+
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+func TestLanguage_Normalize(t *testing.T) {
+	tests := []struct {
+		language *Language
+		word     string
+		expected string
+	}{
+		{English, "Café", "cafe"},
+		{English, "ÆON", "aeon"},
+		{Spanish, "Ñandú", "ñandu"},
+		{Spanish, "N\u0303andu\u0301", "ñandu"},
+		{German, "Straße", "strasse"},
+		{German, "GRÜN", "grün"},
+		{German, "gru\u0308n", "grün"},
+	}
+	for _, tt := range tests {
+		if got := tt.language.Normalize(tt.word); got != tt.expected {
+			t.Errorf("%s: expected %q to normalize to %q, got %q", tt.language.Code, tt.word, tt.expected, got)
+		}
+	}
+	if Length(Spanish.Normalize("n\u0303andu\u0301")) != 5 {
+		t.Error("Expected combining marks not to count as letters")
+	}
+}
+
+func TestLookupLanguage(t *testing.T) {
+	language, err := LookupLanguage("ES")
+	if err != nil || language != Spanish {
+		t.Errorf("Expected the Spanish pack, got %v, %v", language, err)
+	}
+	if _, err := LookupLanguage("xx"); err == nil {
+		t.Error("Expected an unknown language to be rejected")
+	}
+}
+
+func TestGame_MultiByteLetters(t *testing.T) {
+	g := NewGame("ñandu", 6)
+	if err := g.Validate("nandu"); err != nil {
+		t.Errorf("Expected a guess of the same number of letters to be valid: %v", err)
+	}
+	result, err := g.MakeGuess("ñandu")
+	if err != nil {
+		t.Fatalf("MakeGuess failed: %v", err)
+	}
+	if len(result) != 5 || g.State != Won {
+		t.Errorf("Expected 5 hits and a win, got %v", result)
+	}
+}
+
+func TestWordList_Language(t *testing.T) {
+	wordList, err := NewWordList(path.Join(utils.Root, "assets", "es", "words.txt"), WithLanguage(Spanish))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	if !wordList.IsValidWord("ÁRBOL") || !wordList.IsAnswer("señor") || wordList.IsAnswer("senor") {
+		t.Error("Expected accents to fold but ñ to stay a letter")
+	}
+	for _, answer := range wordList.Answers(5) {
+		if Length(answer) != 5 {
+			t.Errorf("Expected %s to have 5 letters", answer)
+		}
+	}
+	if err := wordList.CheckGuess("garçon"); err == nil || err.Error() != "contains letters outside the alphabet" {
+		t.Errorf("Expected a letter outside the alphabet to be reported, got %v", err)
+	}
+	builtIn, err := DefaultWordList(WithLanguage(German))
+	if err != nil {
+		t.Fatalf("Failed to load the built-in German list: %v", err)
+	}
+	if !builtIn.IsAnswer("Straße") || builtIn.Language() != German {
+		t.Error("Expected the built-in German list to spell ß as ss")
+	}
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"errors"
+)
+
+// NewMultiGame combines boards into a single game. Boards should share the
+// same word length and guess limit.
+func NewMultiGame(boards ...Playable) *MultiGame {
+	return &MultiGame{Boards: boards}
+}
+
+// MakeGuess plays guess on every unsolved board. The guess is only played if
+// all of those boards accept it. The returned slice is indexed by board and
+// holds nil for boards that were already solved.
+func (m *MultiGame) MakeGuess(guess string) ([][]LetterResult, error) {
+	if m.Status() != InProgress {
+		return nil, errors.New("game is not in progress")
+	}
+	for _, board := range m.Boards {
+		if board.Status() != InProgress {
+			continue
+		}
+		if err := board.Validate(guess); err != nil {
+			return nil, err
+		}
+	}
+	results := make([][]LetterResult, len(m.Boards))
+	for i, board := range m.Boards {
+		if board.Status() != InProgress {
+			continue
+		}
+		result, err := board.MakeGuess(guess)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+// Status is Won once every board is solved and Lost as soon as any board runs
+// out of guesses.
+func (m *MultiGame) Status() GameState {
+	won := len(m.Boards) > 0
+	for _, board := range m.Boards {
+		switch board.Status() {
+		case Lost:
+			return Lost
+		case InProgress:
+			won = false
+		}
+	}
+	if won {
+		return Won
+	}
+	return InProgress
+}
+
+// Guesses returns how many guesses have been played.
+func (m *MultiGame) Guesses() int {
+	guesses := 0
+	for _, board := range m.Boards {
+		if n := len(board.History()); n > guesses {
+			guesses = n
+		}
+	}
+	return guesses
+}
+
+// Solutions returns the answer of each board.
+func (m *MultiGame) Solutions() []string {
+	solutions := make([]string, len(m.Boards))
+	for i, board := range m.Boards {
+		solutions[i] = board.Solution()
+	}
+	return solutions
+}
+
+// Lies lists the falsified tiles of every board.
+func (m *MultiGame) Lies() []Lie {
+	var lies []Lie
+	for i, board := range m.Boards {
+		for _, lie := range Lies(board.History(), board.Solution()) {
+			lie.Board = i
+			lies = append(lies, lie)
+		}
+	}
+	return lies
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestMultiGame_SharedGuesses(t *testing.T) {
+	game := NewMultiGame(NewGame("apple", 6), NewGame("grape", 6))
+	results, err := game.MakeGuess("grape")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0] == nil || results[1] == nil {
+		t.Fatalf("Expected feedback for both boards, got %v", results)
+	}
+	if game.Status() != InProgress {
+		t.Errorf("Expected game to continue until every board is solved, got %v", game.Status())
+	}
+	results, err = game.MakeGuess("apple")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if results[1] != nil {
+		t.Errorf("Expected no feedback for the solved board, got %v", results[1])
+	}
+	if game.Status() != Won {
+		t.Errorf("Expected game to be won, got %v", game.Status())
+	}
+	if game.Guesses() != 2 {
+		t.Errorf("Expected 2 guesses, got %d", game.Guesses())
+	}
+}
+
+func TestMultiGame_LostWhenBudgetRunsOut(t *testing.T) {
+	game := NewMultiGame(NewGame("apple", 2), NewGame("grape", 2))
+	game.MakeGuess("apple")
+	game.MakeGuess("lemon")
+	if game.Status() != Lost {
+		t.Errorf("Expected game to be lost, got %v", game.Status())
+	}
+	if _, err := game.MakeGuess("grape"); err == nil {
+		t.Errorf("Expected error when guessing after game over")
+	}
+}
+
+func TestMultiGame_RejectsGuessOnAnyBoard(t *testing.T) {
+	game := NewMultiGame(NewGame("lemon", 6), NewGame("grape", 6, WithHardMode(true)))
+	game.MakeGuess("apple")
+	// The second board must reuse the p revealed by "apple", so "table" is
+	// rejected and not played on the first board either.
+	if _, err := game.MakeGuess("table"); err == nil {
+		t.Fatalf("Expected hard mode error from the second board")
+	}
+	if len(game.Boards[0].History()) != 1 {
+		t.Errorf("Expected rejected guess not to be played, got %d attempts", len(game.Boards[0].History()))
+	}
+}
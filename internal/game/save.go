@@ -0,0 +1,72 @@
+package game
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// savedGameVersion is bumped whenever SavedGame changes incompatibly.
+const savedGameVersion = 1
+
+// Save returns the serialized form of the game.
+func (g *Game) Save() (*SavedGame, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("save failed: %w", err)
+	}
+	saltHex := hex.EncodeToString(salt)
+	return &SavedGame{
+		Version:    savedGameVersion,
+		Salt:       saltHex,
+		AnswerHash: hashAnswer(saltHex, g.Answer),
+		WordLength: Length(g.Answer),
+		MaxGuesses: g.MaxGuesses,
+		HardMode:   g.HardMode,
+		Attempts:   g.Attempts,
+		State:      g.State,
+	}, nil
+}
+
+// Restore rebuilds the saved game, looking up its answer in answers.
+func (s *SavedGame) Restore(answers AnswerProvider) (*Game, error) {
+	if s.Version != savedGameVersion {
+		return nil, fmt.Errorf("unsupported saved game version: %d", s.Version)
+	}
+	for _, answer := range answers.Answers(s.WordLength) {
+		if hashAnswer(s.Salt, answer) != s.AnswerHash {
+			continue
+		}
+		g := NewGame(answer, s.MaxGuesses, WithHardMode(s.HardMode))
+		g.Attempts = append(g.Attempts, s.Attempts...)
+		g.State = s.State
+		return g, nil
+	}
+	return nil, errors.New("saved answer is not in the word list")
+}
+
+// WriteSavedGames encodes saved games as JSON.
+func WriteSavedGames(w io.Writer, saved []*SavedGame) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(saved)
+}
+
+// ReadSavedGames decodes saved games written by WriteSavedGames.
+func ReadSavedGames(r io.Reader) ([]*SavedGame, error) {
+	var saved []*SavedGame
+	if err := json.NewDecoder(r).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("read saved games failed: %w", err)
+	}
+	return saved, nil
+}
+
+func hashAnswer(salt, answer string) string {
+	sum := sha256.Sum256([]byte(salt + strings.ToLower(answer)))
+	return hex.EncodeToString(sum[:])
+}
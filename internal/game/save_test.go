@@ -0,0 +1,62 @@
+package game
+
+import (
+	"bytes"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+func TestSaveRestore(t *testing.T) {
+	wordList, err := NewWordList(path.Join(utils.Root, "assets", "words.txt"))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	game := NewGame("apple", 6, WithHardMode(true))
+	game.MakeGuess("grape")
+	saved, err := game.Save()
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	saved.Mode, saved.Boards, saved.Puzzle = Daily, 1, 7
+	var buf bytes.Buffer
+	if err := WriteSavedGames(&buf, []*SavedGame{saved}); err != nil {
+		t.Fatalf("WriteSavedGames failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "apple") {
+		t.Errorf("Expected the answer not to appear in the saved game: %s", buf.String())
+	}
+	loaded, err := ReadSavedGames(&buf)
+	if err != nil {
+		t.Fatalf("ReadSavedGames failed: %v", err)
+	}
+	if got := loaded[0]; got.Mode != Daily || got.Boards != 1 || got.Puzzle != 7 {
+		t.Errorf("Expected the session to be saved, got %s %d %d", got.Mode, got.Boards, got.Puzzle)
+	}
+	restored, err := loaded[0].Restore(wordList)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if restored.Answer != "apple" || !restored.HardMode || restored.MaxGuesses != 6 {
+		t.Errorf("Expected restored settings to match, got %+v", restored)
+	}
+	if len(restored.Attempts) != 1 || restored.State != InProgress {
+		t.Errorf("Expected one attempt in progress, got %d attempts, state %v", len(restored.Attempts), restored.State)
+	}
+	if _, err := restored.MakeGuess("apple"); err != nil || restored.State != Won {
+		t.Errorf("Expected restored game to be playable, got %v, state %v", err, restored.State)
+	}
+}
+
+func TestRestore_UnknownAnswer(t *testing.T) {
+	wordList, err := NewWordList(path.Join(utils.Root, "assets", "words.txt"))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	saved, _ := NewGame("zzzzz", 6).Save()
+	if _, err := saved.Restore(wordList); err == nil {
+		t.Errorf("Expected error restoring an answer missing from the word list")
+	}
+}
@@ -1,5 +1,13 @@
 package game
 
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"unicode"
+)
+
 type GameState int
 
 const (
@@ -14,22 +22,349 @@ const (
 	Miss MatchType = iota
 	Present
 	Hit
+	// Higher means the answer's letter in this position comes later in the
+	// alphabet than the guessed letter.
+	Higher
+	// Lower means the answer's letter in this position comes earlier in the
+	// alphabet than the guessed letter.
+	Lower
+	// matchTypes is the number of match types.
+	matchTypes = iota
 )
 
+func (t MatchType) String() string {
+	switch t {
+	case Miss:
+		return "miss"
+	case Present:
+		return "present"
+	case Hit:
+		return "hit"
+	case Higher:
+		return "higher"
+	case Lower:
+		return "lower"
+	}
+	return "unknown"
+}
+
+// NoPosition marks a result that is not tied to a position in the guess,
+// such as a Mastermind peg.
+const NoPosition = -1
+
 type LetterResult struct {
 	Letter    rune      `json:"letter"`
 	Position  int       `json:"position"`
 	MatchType MatchType `json:"match_type"`
+	// Lie is set on the tile a LyingEvaluator falsified.
+	Lie bool `json:"lie,omitempty"`
+}
+
+// Lie is a tile that a LyingEvaluator falsified.
+type Lie struct {
+	Board int `json:"board"`
+	// Attempt is the index of the row in the board's history.
+	Attempt  int       `json:"attempt"`
+	Position int       `json:"position"`
+	Letter   rune      `json:"letter"`
+	Shown    MatchType `json:"shown"`
+	Actual   MatchType `json:"actual"`
+}
+
+// Evaluator produces the feedback for a guess against an answer of the same
+// length.
+type Evaluator interface {
+	Evaluate(guess, answer string) []LetterResult
 }
 
+// EvaluatorFunc adapts a scoring function such as Score to an Evaluator.
+type EvaluatorFunc func(guess, answer string) []LetterResult
+
+// LyingEvaluator wraps another evaluator and falsifies exactly one tile of
+// every row. It draws from a random source, so it is not safe for concurrent
+// use.
+type LyingEvaluator struct {
+	base Evaluator
+	rng  *rand.Rand
+}
+
+// KeyState is the best-known status of a letter across all attempts. States
+// are ordered so that a later attempt can only raise a letter's state.
+type KeyState int
+
+const (
+	Unused KeyState = iota
+	KeyMiss
+	KeyPresent
+	KeyHit
+)
+
+// Keyboard maps letters to their best-known state. Letters without an entry
+// are Unused.
+type Keyboard map[rune]KeyState
+
 type Game struct {
 	Answer     string
 	MaxGuesses int
+	HardMode   bool
 	Attempts   [][]LetterResult
 	State      GameState
+	// Evaluator scores guesses. Score is used when it is nil.
+	Evaluator Evaluator
+}
+
+// Playable is the API shared by every game variant, so that hosts such as the
+// standalone CLI and the multiplayer lobby can run any of them.
+type Playable interface {
+	MakeGuess(guess string) ([]LetterResult, error)
+	// Validate returns the error MakeGuess would return for guess, without
+	// playing it.
+	Validate(guess string) error
+	Status() GameState
+	History() [][]LetterResult
+	// Solution returns the answer, or one answer consistent with the
+	// feedback so far for variants that do not fix it up front.
+	Solution() string
+}
+
+// Mode selects the game variant played.
+type Mode string
+
+const (
+	Classic Mode = "classic"
+	// Absurd picks no answer up front and answers every guess with the
+	// feedback that keeps the most candidates alive.
+	Absurd Mode = "absurd"
+	// Daily plays the puzzle of the day, which is the same for everyone
+	// sharing the word list and secret.
+	Daily Mode = "daily"
+	// Fibble plays a random answer with one lying tile in every feedback
+	// row. The lies are revealed when the game ends.
+	Fibble Mode = "fibble"
+	// Nerdle replaces words with arithmetic equations such as 12+35=47.
+	Nerdle Mode = "nerdle"
+)
+
+// Modes lists every game mode.
+var Modes = []Mode{Classic, Absurd, Daily, Fibble, Nerdle}
+
+// Feedback selects the evaluator that scores guesses.
+type Feedback string
+
+const (
+	// WordleFeedback marks every letter Hit, Present or Miss.
+	WordleFeedback Feedback = "wordle"
+	// MastermindFeedback only reveals how many letters are hits and how many
+	// are present.
+	MastermindFeedback Feedback = "mastermind"
+	// PeaksFeedback marks every letter Hit, Higher or Lower.
+	PeaksFeedback Feedback = "peaks"
+	// LyingFeedback is WordleFeedback with one false tile in every row.
+	LyingFeedback Feedback = "lying"
+)
+
+// Feedbacks lists every feedback style.
+var Feedbacks = []Feedback{WordleFeedback, MastermindFeedback, PeaksFeedback, LyingFeedback}
+
+// Tier groups answers by difficulty. Each tier holds about a third of the
+// answers of a given length.
+type Tier string
+
+const (
+	// AnyTier places no limit on difficulty.
+	AnyTier    Tier = "any"
+	EasyTier   Tier = "easy"
+	NormalTier Tier = "normal"
+	HardTier   Tier = "hard"
+)
+
+// Tiers lists every difficulty tier, easiest first after AnyTier.
+var Tiers = []Tier{AnyTier, EasyTier, NormalTier, HardTier}
+
+// AbsurdGame is an adversarial Game. Answer always holds one of the
+// remaining candidates and only becomes final when the game ends.
+type AbsurdGame struct {
+	Game
+	Candidates []string
 }
 
+// MultiGame plays several boards at once (Dordle, Quordle and so on). Every
+// guess is applied to each unsolved board, so all boards share one guess
+// budget, and the game is won only when every board is solved.
+type MultiGame struct {
+	Boards []Playable
+}
+
+// Option configures a Game created by NewGame.
+type Option func(*Game)
+
+// HardModeRule identifies which hard mode constraint a guess broke.
+type HardModeRule int
+
+const (
+	// KeepHit requires a letter revealed as a Hit to stay in its position.
+	KeepHit HardModeRule = iota
+	// UsePresent requires a letter revealed as Present to appear in the guess.
+	UsePresent
+)
+
+func (r HardModeRule) String() string {
+	switch r {
+	case KeepHit:
+		return "keep_hit"
+	case UsePresent:
+		return "use_present"
+	}
+	return "unknown"
+}
+
+// HardModeError is returned by MakeGuess when a guess ignores a hint revealed
+// by an earlier attempt.
+type HardModeError struct {
+	Rule     HardModeRule
+	Letter   rune
+	Position int
+}
+
+func (e *HardModeError) Error() string {
+	letter := unicode.ToUpper(e.Letter)
+	switch e.Rule {
+	case KeepHit:
+		return fmt.Sprintf("hard mode: %c must stay in position %d", letter, e.Position+1)
+	case UsePresent:
+		return fmt.Sprintf("hard mode: guess must contain %c", letter)
+	}
+	return "hard mode: guess does not use revealed hints"
+}
+
+// Supported equation lengths. EquationLength is the length used by Nerdle.
+const (
+	MinEquationLength = 5
+	MaxEquationLength = 8
+	EquationLength    = 8
+)
+
+// Supported word lengths. DefaultWordLength is used when no length is set.
+const (
+	MinWordLength     = 4
+	MaxWordLength     = 11
+	DefaultWordLength = 5
+)
+
+// WordList holds the answer pool and the dictionary of allowed guesses.
+// Answers are always accepted as guesses.
 type WordList struct {
 	words []string
 	index map[string]int
+	// weights holds the relative chance of each answer being picked.
+	weights map[string]float64
+	// difficulty rates each answer, higher being harder, and tiers splits
+	// the answers of each length into thirds by that rating.
+	difficulty map[string]float64
+	tiers      map[string]Tier
+	byLength   map[int][]string
+	allowed    map[string]struct{}
+	// allowedByLength holds allowed guesses that are not answers.
+	allowedByLength map[int][]string
+	blocked         map[string]struct{}
+	language        *Language
+	// rng picks random answers when set; mu guards it since *rand.Rand is
+	// not safe for concurrent use.
+	rng *rand.Rand
+	mu  sync.Mutex
+}
+
+// Language is a language pack: the alphabet words are spelled in and the
+// rules that normalize words before they are compared.
+type Language struct {
+	// Code identifies the language, such as "en".
+	Code string
+	Name string
+	// Alphabet holds every letter a normalized word may contain.
+	Alphabet string
+	// Layout lists the keyboard rows shown to players.
+	Layout []string
+	// Substitutions lists pairs of strings replaced after lowercasing, such
+	// as "ß", "ss".
+	Substitutions []string
+	// Fold maps accented letters to the letter they are played as. Letters
+	// missing from Fold, such as ñ in Spanish, are letters of their own.
+	Fold map[rune]rune
+	// WordListPath and AllowedGuessesPath are the language's default lists.
+	// An empty AllowedGuessesPath accepts only answers as guesses.
+	WordListPath       string
+	AllowedGuessesPath string
+	// words and allowed are the built-in copies of the default lists.
+	words    []byte
+	allowed  []byte
+	replacer *strings.Replacer
+}
+
+// AnswerProvider supplies the answers of a game and decides which guesses
+// are accepted. WordList provides words and EquationPool provides equations.
+type AnswerProvider interface {
+	// Answers returns the answers of the given length.
+	Answers(length int) []string
+	// Guesses returns the accepted guesses of the given length that can be
+	// listed, answers first.
+	Guesses(length int) []string
+	// CheckGuess returns an error describing why guess is not accepted.
+	CheckGuess(guess string) error
+	// RandomWordsOfLength returns n distinct random answers.
+	RandomWordsOfLength(length, n int) []string
+	// RandomWordsFrom is like RandomWordsOfLength but draws from r, and only
+	// picks answers for which exclude returns true if it has to.
+	RandomWordsFrom(r *rand.Rand, length, n int, exclude func(string) bool) []string
+}
+
+// EquationPool provides Nerdle answers: arithmetic equations such as
+// 12+35=47. Any true equation of the right length is accepted as a guess.
+type EquationPool struct {
+	byLength map[int][]string
+	rng      *rand.Rand
+	mu       sync.Mutex
+}
+
+type wordListConfig struct {
+	allowedGuessesPath string
+	blocklistPath      string
+	language           *Language
+	source             rand.Source
+}
+
+// WordListOption configures a WordList created by NewWordList.
+type WordListOption func(*wordListConfig)
+
+// Knowledge summarises what a set of attempts reveals about the answer.
+type Knowledge struct {
+	Length int
+	// Fixed holds the letter known to be at each position, or 0 if unknown.
+	Fixed []rune
+	// Excluded holds, per position, the letters known not to be there.
+	Excluded []map[rune]bool
+	// MinCount is the fewest times each letter must appear.
+	MinCount map[rune]int
+	// MaxCount is the most times a letter may appear. Letters without an
+	// entry are unbounded, and a zero entry excludes the letter entirely.
+	MaxCount map[rune]int
+}
+
+// SavedGame is the stable serialized form of a Game. The answer is stored as
+// a salted hash so a save file does not give it away at a glance; Restore
+// recovers it by hashing the answers in a word list.
+type SavedGame struct {
+	Version    int              `json:"version"`
+	Salt       string           `json:"salt"`
+	AnswerHash string           `json:"answer_hash"`
+	WordLength int              `json:"word_length"`
+	MaxGuesses int              `json:"max_guesses"`
+	HardMode   bool             `json:"hard_mode"`
+	Attempts   [][]LetterResult `json:"attempts"`
+	State      GameState        `json:"state"`
+	// Mode, Boards and Puzzle describe the session the board belongs to, so
+	// a save is only resumed by the same kind of game. Save leaves them for
+	// the caller to fill in.
+	Mode   Mode `json:"mode,omitempty"`
+	Boards int  `json:"boards,omitempty"`
+	Puzzle int  `json:"puzzle,omitempty"`
 }
@@ -1,32 +1,315 @@
 package game
 
 import (
-	"strings"
+	"bytes"
+	"errors"
+	"math/rand"
 
 	"github.com/tomlaws/wordle/pkg/utils"
 )
 
-func NewWordList(path string) (*WordList, error) {
-	words, err := utils.LoadWords(path)
+// NewWordList loads the answer pool from path. Only answers are accepted as
+// guesses unless a larger dictionary is added with WithAllowedGuesses. Each
+// line may give a weight after the word, such as a frequency count, to make
+// the word more or less likely to be picked as a random answer, followed by
+// a difficulty rating. Answers without a rating are rated by
+// EstimateDifficulty. The file may be gzip-compressed. Words are normalized
+// by the language set with WithLanguage, English by default.
+func NewWordList(path string, opts ...WordListOption) (*WordList, error) {
+	var config wordListConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	entries, err := utils.LoadWordEntries(path)
+	if err != nil {
+		return nil, err
+	}
+	var guesses []string
+	if config.allowedGuessesPath != "" {
+		if guesses, err = utils.LoadWords(config.allowedGuessesPath); err != nil {
+			return nil, err
+		}
+	}
+	blocked, err := loadBlocklist(config)
 	if err != nil {
 		return nil, err
 	}
+	return newWordList(entries, guesses, blocked, config), nil
+}
+
+// DefaultWordList returns the word list of the language built into the
+// binary, which accepts the language's built-in allowed guesses.
+// WithAllowedGuesses is ignored, but WithBlocklist applies.
+func DefaultWordList(opts ...WordListOption) (*WordList, error) {
+	var config wordListConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	language := config.languageOrDefault()
+	entries, err := utils.ReadWordEntries(bytes.NewReader(language.words), language.WordListPath)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := utils.ReadWordEntries(bytes.NewReader(language.allowed), language.AllowedGuessesPath)
+	if err != nil {
+		return nil, err
+	}
+	guesses := make([]string, len(allowed))
+	for i, entry := range allowed {
+		guesses[i] = entry.Word
+	}
+	blocked, err := loadBlocklist(config)
+	if err != nil {
+		return nil, err
+	}
+	return newWordList(entries, guesses, blocked, config), nil
+}
+
+// newWordList builds a word list from the answer entries. Blocked answers are
+// accepted as guesses instead. A word listed twice keeps its first entry, so
+// it is not weighted twice or dealt on two boards of one match.
+func newWordList(entries []utils.WordEntry, guesses []string, blocked map[string]struct{}, config wordListConfig) *WordList {
+	language := config.languageOrDefault()
+	words := make([]string, 0, len(entries))
 	index := make(map[string]int)
-	for i, word := range words {
-		index[word] = i
+	weightOf := make(map[string]float64)
+	difficulty := make(map[string]float64)
+	byLength := make(map[int][]string)
+	for _, entry := range entries {
+		// Guesses are normalized before lookup, so stored words must be too.
+		entry.Word = language.Normalize(entry.Word)
+		if _, isBlocked := blocked[entry.Word]; isBlocked {
+			guesses = append(guesses, entry.Word)
+			continue
+		}
+		if _, exists := index[entry.Word]; exists {
+			continue
+		}
+		index[entry.Word] = len(words)
+		words = append(words, entry.Word)
+		weightOf[entry.Word] = entry.Weight
+		if entry.Difficulty > 0 {
+			difficulty[entry.Word] = entry.Difficulty
+		}
+		length := Length(entry.Word)
+		byLength[length] = append(byLength[length], entry.Word)
+	}
+	for _, answers := range byLength {
+		rateAnswers(answers, difficulty)
+	}
+	allowed := make(map[string]struct{})
+	allowedByLength := make(map[int][]string)
+	for _, word := range guesses {
+		word = language.Normalize(word)
+		if _, exists := allowed[word]; exists {
+			continue
+		}
+		allowed[word] = struct{}{}
+		if _, isAnswer := index[word]; !isAnswer {
+			allowedByLength[Length(word)] = append(allowedByLength[Length(word)], word)
+		}
+	}
+	wordList := &WordList{
+		words:           words,
+		index:           index,
+		weights:         weightOf,
+		difficulty:      difficulty,
+		tiers:           assignTiers(byLength, difficulty),
+		byLength:        byLength,
+		allowed:         allowed,
+		allowedByLength: allowedByLength,
+		blocked:         blocked,
+		language:        language,
+	}
+	if config.source != nil {
+		wordList.rng = rand.New(config.source)
+	}
+	return wordList
+}
+
+// WithAllowedGuesses loads an additional dictionary of words that are
+// accepted as guesses but never chosen as answers.
+func WithAllowedGuesses(path string) WordListOption {
+	return func(c *wordListConfig) {
+		c.allowedGuessesPath = path
+	}
+}
+
+// WithBlocklist loads words that are never chosen as answers, such as
+// offensive words, from path. They are still accepted as guesses, but
+// Censor masks them.
+func WithBlocklist(path string) WordListOption {
+	return func(c *wordListConfig) {
+		c.blocklistPath = path
+	}
+}
+
+// WithLanguage normalizes words with the rules of language.
+func WithLanguage(language *Language) WordListOption {
+	return func(c *wordListConfig) {
+		c.language = language
+	}
+}
+
+// languageOrDefault returns the configured language, or English.
+func (c wordListConfig) languageOrDefault() *Language {
+	if c.language == nil {
+		return English
+	}
+	return c.language
+}
+
+// Language returns the language the list's words are normalized by.
+func (wl *WordList) Language() *Language {
+	return wl.language
+}
+
+// WithSource makes the word list draw its random answers from source instead
+// of the global random source, so the sequence of answers can be reproduced.
+func WithSource(source rand.Source) WordListOption {
+	return func(c *wordListConfig) {
+		c.source = source
+	}
+}
+
+// float64 returns a random number in [0, 1) from the word list's source.
+func (wl *WordList) float64() float64 {
+	if wl.rng == nil {
+		return rand.Float64()
 	}
-	return &WordList{words: words, index: index}, nil
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	return wl.rng.Float64()
+}
+
+// weight returns how likely word is to be picked relative to other answers.
+func (wl *WordList) weight(word string) float64 {
+	return wl.weights[word]
 }
 
 func (wl *WordList) RandomWord() string {
-	if len(wl.words) == 0 {
+	words := pickWeighted(wl.words, wl.weight, wl.float64, 1, nil)
+	if len(words) == 0 {
 		return ""
 	}
-	return wl.words[utils.RandomInt(0, len(wl.words)-1)]
+	return words[0]
+}
+
+// RandomWordOfLength returns a random word with the given number of letters,
+// or an empty string if the list has none.
+func (wl *WordList) RandomWordOfLength(length int) string {
+	words := wl.randomWords(wl.float64, length, 1, nil)
+	if len(words) == 0 {
+		return ""
+	}
+	return words[0]
+}
+
+// CountOfLength returns how many words in the list have the given length.
+func (wl *WordList) CountOfLength(length int) int {
+	return len(wl.byLength[length])
+}
+
+// Answers returns the answers with the given number of letters.
+func (wl *WordList) Answers(length int) []string {
+	return append([]string(nil), wl.byLength[length]...)
+}
+
+// Guesses returns every accepted guess with the given number of letters,
+// answers first.
+func (wl *WordList) Guesses(length int) []string {
+	guesses := make([]string, 0, len(wl.byLength[length])+len(wl.allowedByLength[length]))
+	guesses = append(guesses, wl.byLength[length]...)
+	return append(guesses, wl.allowedByLength[length]...)
 }
 
+// IsValidWord reports whether word is an answer or an allowed guess.
 func (wl *WordList) IsValidWord(word string) bool {
-	word = strings.ToLower(word)
-	_, exists := wl.index[word]
+	word = wl.language.Normalize(word)
+	if _, exists := wl.index[word]; exists {
+		return true
+	}
+	_, exists := wl.allowed[word]
 	return exists
 }
+
+// CheckGuess returns an error if guess is not an answer or an allowed guess.
+func (wl *WordList) CheckGuess(guess string) error {
+	if !wl.IsValidWord(guess) {
+		if !wl.language.InAlphabet(wl.language.Normalize(guess)) {
+			return errors.New("contains letters outside the alphabet")
+		}
+		return errors.New("not a valid word")
+	}
+	return nil
+}
+
+// IsAnswer reports whether word belongs to the answer pool.
+func (wl *WordList) IsAnswer(word string) bool {
+	_, exists := wl.index[wl.language.Normalize(word)]
+	return exists
+}
+
+// IsValidLength reports whether length is a supported word length.
+func IsValidLength(length int) bool {
+	return length >= MinWordLength && length <= MaxWordLength
+}
+
+// RandomWordsOfLength returns n distinct random words with the given number of
+// letters, or fewer if the list does not have enough. Words are picked in
+// proportion to their weights.
+func (wl *WordList) RandomWordsOfLength(length, n int) []string {
+	return wl.randomWords(wl.float64, length, n, nil)
+}
+
+// RandomWordsFrom is like RandomWordsOfLength but draws from r, so callers
+// can reproduce a selection from their own seed. Words for which exclude
+// returns true are only picked if there are not enough other words; exclude
+// may be nil.
+func (wl *WordList) RandomWordsFrom(r *rand.Rand, length, n int, exclude func(string) bool) []string {
+	return wl.randomWords(r.Float64, length, n, exclude)
+}
+
+func (wl *WordList) randomWords(float64 func() float64, length, n int, exclude func(string) bool) []string {
+	return pickWeighted(wl.byLength[length], wl.weight, float64, n, exclude)
+}
+
+// pickWeighted returns n distinct entries of words, picking each one with a
+// probability proportional to its weight. Words for which exclude returns
+// true are only picked once every other word has been.
+func pickWeighted(words []string, weight func(string) float64, float64 func() float64, n int, exclude func(string) bool) []string {
+	picked := make([]bool, len(words))
+	var result []string
+	for _, allowExcluded := range []bool{false, true} {
+		eligible := func(i int) bool {
+			return !picked[i] && (allowExcluded || exclude == nil || !exclude(words[i]))
+		}
+		for len(result) < n {
+			total := 0.0
+			for i, word := range words {
+				if eligible(i) {
+					total += weight(word)
+				}
+			}
+			if total == 0 {
+				break
+			}
+			x := float64() * total
+			chosen := -1
+			for i, word := range words {
+				if !eligible(i) {
+					continue
+				}
+				// Keep the last eligible word in case rounding leaves x
+				// slightly above zero.
+				chosen = i
+				if x -= weight(word); x < 0 {
+					break
+				}
+			}
+			picked[chosen] = true
+			result = append(result, words[chosen])
+		}
+	}
+	return result
+}
@@ -1,6 +1,8 @@
 package game
 
 import (
+	"math/rand"
+	"os"
 	"path"
 	"testing"
 
@@ -75,3 +77,170 @@ func TestIsValidWord(t *testing.T) {
 		}
 	}
 }
+
+func TestRandomWordOfLength(t *testing.T) {
+	wordList, err := NewWordList(path.Join(utils.Root, "assets", "words.txt"))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	for _, length := range []int{5, 6, 7} {
+		if wordList.CountOfLength(length) == 0 {
+			t.Fatalf("Expected words of length %d in the list", length)
+		}
+		word := wordList.RandomWordOfLength(length)
+		if len(word) != length {
+			t.Errorf("Expected a %d-letter word, got %q", length, word)
+		}
+		if !wordList.IsValidWord(word) {
+			t.Errorf("RandomWordOfLength returned a word not in the list: %s", word)
+		}
+	}
+	if word := wordList.RandomWordOfLength(MaxWordLength + 1); word != "" {
+		t.Errorf("Expected empty string for unsupported length, got %q", word)
+	}
+}
+
+func TestWordListAllowedGuesses(t *testing.T) {
+	wordList, err := NewWordList(
+		path.Join(utils.Root, "assets", "words.txt"),
+		WithAllowedGuesses(path.Join(utils.Root, "assets", "allowed.txt")),
+	)
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	if !wordList.IsValidWord("CRANE") {
+		t.Errorf("Expected allowed guess crane to be a valid word")
+	}
+	if wordList.IsAnswer("crane") {
+		t.Errorf("Expected allowed guess crane not to be an answer")
+	}
+	if !wordList.IsValidWord("apple") || !wordList.IsAnswer("apple") {
+		t.Errorf("Expected answer apple to be both valid and an answer")
+	}
+	for i := 0; i < 100; i++ {
+		if word := wordList.RandomWordOfLength(5); !wordList.IsAnswer(word) {
+			t.Fatalf("RandomWordOfLength returned a word outside the answer pool: %s", word)
+		}
+	}
+}
+
+func TestWordListAllowedGuesses_NonExistentFile(t *testing.T) {
+	_, err := NewWordList(path.Join(utils.Root, "assets", "words.txt"), WithAllowedGuesses("non_existent_file.txt"))
+	if err == nil {
+		t.Fatalf("Expected error for non-existent allowed guesses file, got nil")
+	}
+}
+
+func TestWordListWithSource(t *testing.T) {
+	wordListPath := path.Join(utils.Root, "assets", "words.txt")
+	first, err := NewWordList(wordListPath, WithSource(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	second, err := NewWordList(wordListPath, WithSource(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		a, b := first.RandomWordOfLength(5), second.RandomWordOfLength(5)
+		if a != b {
+			t.Fatalf("Expected the same sequence for the same seed, got %s and %s at %d", a, b, i)
+		}
+	}
+	a := first.RandomWordsFrom(rand.New(rand.NewSource(7)), 5, 3, nil)
+	b := second.RandomWordsFrom(rand.New(rand.NewSource(7)), 5, 3, nil)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Errorf("Expected RandomWordsFrom to be reproducible, got %v and %v", a, b)
+		}
+	}
+}
+
+func TestWordListWeights(t *testing.T) {
+	wordListPath := path.Join(t.TempDir(), "weighted.txt")
+	if err := os.WriteFile(wordListPath, []byte("apple 1000\nbeach\nbread 0.001\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write word list: %v", err)
+	}
+	wordList, err := NewWordList(wordListPath, WithSource(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	if !wordList.IsAnswer("bread") {
+		t.Error("Expected weighted words to be answers")
+	}
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		counts[wordList.RandomWordOfLength(5)]++
+	}
+	if counts["apple"] < 950 {
+		t.Errorf("Expected the heavy word to be picked most of the time, got %v", counts)
+	}
+	words := wordList.RandomWordsOfLength(5, 3)
+	if len(words) != 3 {
+		t.Errorf("Expected every word to be picked once, got %v", words)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		words := wordList.RandomWordsFrom(r, 5, 1, func(word string) bool { return word == "apple" })
+		if len(words) != 1 || words[0] == "apple" {
+			t.Fatalf("Expected an excluded word to be avoided, got %v", words)
+		}
+	}
+}
+
+func TestWordListDuplicates(t *testing.T) {
+	wordListPath := path.Join(t.TempDir(), "duplicates.txt")
+	if err := os.WriteFile(wordListPath, []byte("apple\nbeach\nApple 5\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write word list: %v", err)
+	}
+	wordList, err := NewWordList(wordListPath)
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	if answers := wordList.Answers(5); len(answers) != 2 {
+		t.Errorf("Expected a duplicate to be listed once, got %v", answers)
+	}
+	for i := 0; i < 20; i++ {
+		if words := wordList.RandomWordsOfLength(5, 2); len(words) != 2 || words[0] == words[1] {
+			t.Fatalf("Expected two different words, got %v", words)
+		}
+	}
+}
+
+func TestDefaultWordList(t *testing.T) {
+	wordList, err := DefaultWordList()
+	if err != nil {
+		t.Fatalf("Failed to load the built-in word list: %v", err)
+	}
+	onDisk, err := NewWordList(path.Join(utils.Root, "assets", "words.txt"), WithAllowedGuesses(path.Join(utils.Root, "assets", "allowed.txt")))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	if len(wordList.Answers(5)) != len(onDisk.Answers(5)) || len(wordList.Guesses(5)) != len(onDisk.Guesses(5)) {
+		t.Errorf("Expected the built-in list to match assets, got %d answers and %d guesses", len(wordList.Answers(5)), len(wordList.Guesses(5)))
+	}
+}
+
+func TestWordListLowercasesStoredWords(t *testing.T) {
+	dir := t.TempDir()
+	wordListPath := path.Join(dir, "words.txt")
+	allowedPath := path.Join(dir, "allowed.txt")
+	if err := os.WriteFile(wordListPath, []byte("Apple\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write word list: %v", err)
+	}
+	if err := os.WriteFile(allowedPath, []byte("CRANE\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write allowed guesses: %v", err)
+	}
+	wordList, err := NewWordList(wordListPath, WithAllowedGuesses(allowedPath))
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	for _, word := range []string{"apple", "APPLE", "crane"} {
+		if !wordList.IsValidWord(word) {
+			t.Errorf("Expected %s to be valid", word)
+		}
+	}
+	if answers := wordList.Answers(5); len(answers) != 1 || answers[0] != "apple" {
+		t.Errorf("Expected the answer to be lowercased, got %v", answers)
+	}
+}
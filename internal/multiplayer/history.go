@@ -0,0 +1,52 @@
+package multiplayer
+
+// Default sizes of the repeat-avoidance windows, counted in answers.
+const (
+	DefaultPlayerRepeatWindow = 30
+	DefaultServerRepeatWindow = 10
+)
+
+func newAnswerHistory(playerWindow, serverWindow int) *answerHistory {
+	return &answerHistory{
+		playerWindow: playerWindow,
+		serverWindow: serverWindow,
+		players:      make(map[string][]string),
+	}
+}
+
+// Add records answers as dealt to the given players.
+func (h *answerHistory) Add(answers []string, nicknames ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.server = remember(h.server, answers, h.serverWindow)
+	for _, nickname := range nicknames {
+		h.players[nickname] = remember(h.players[nickname], answers, h.playerWindow)
+	}
+}
+
+// Recent returns the answers dealt within the server window or within the
+// window of any of the given players.
+func (h *answerHistory) Recent(nicknames ...string) map[string]bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	recent := make(map[string]bool)
+	for _, answer := range h.server {
+		recent[answer] = true
+	}
+	for _, nickname := range nicknames {
+		for _, answer := range h.players[nickname] {
+			recent[answer] = true
+		}
+	}
+	return recent
+}
+
+// remember appends answers to recent and drops the oldest entries beyond
+// window.
+func remember(recent, answers []string, window int) []string {
+	recent = append(recent, answers...)
+	if len(recent) > window {
+		recent = append([]string(nil), recent[len(recent)-window:]...)
+	}
+	return recent
+}
@@ -0,0 +1,32 @@
+package multiplayer
+
+import (
+	"testing"
+)
+
+func TestAnswerHistory_Windows(t *testing.T) {
+	h := newAnswerHistory(3, 1)
+	h.Add([]string{"apple"}, "alice", "bob")
+	h.Add([]string{"beach"}, "alice", "carol")
+	h.Add([]string{"bread", "chair"}, "alice", "dave")
+
+	recent := h.Recent("alice")
+	for _, answer := range []string{"beach", "bread", "chair"} {
+		if !recent[answer] {
+			t.Errorf("Expected %s to be recent for alice", answer)
+		}
+	}
+	if recent["apple"] {
+		t.Error("Expected apple to have left alice's window")
+	}
+
+	recent = h.Recent("bob")
+	if !recent["apple"] || !recent["chair"] || recent["beach"] {
+		t.Errorf("Expected bob's answers and the server's last answer, got %v", recent)
+	}
+
+	recent = h.Recent("erin")
+	if len(recent) != 1 || !recent["chair"] {
+		t.Errorf("Expected only the server's last answer for a new player, got %v", recent)
+	}
+}
@@ -1,39 +1,247 @@
 package multiplayer
 
 import (
+	"fmt"
 	"log"
 	"math/rand"
 	"time"
 
 	"github.com/tomlaws/wordle/internal/game"
 	"github.com/tomlaws/wordle/internal/protocol"
+	"github.com/tomlaws/wordle/internal/share"
 )
 
-func NewLobby(wordListPath string, maxGuesses int, thinkTime int) *Lobby {
-	wordList, err := game.NewWordList(wordListPath)
-	if err != nil {
-		log.Fatal("Error loading word list:", err)
-	}
+func NewLobby(wordListPath string, maxGuesses int, thinkTime int, opts ...LobbyOption) *Lobby {
 	lobby := &Lobby{
-		wordList:   wordList,
-		maxGuesses: maxGuesses,
-		thinkTime:  thinkTime,
-		queue:      make(chan *Player, 100),
+		wordListPath: wordListPath,
+		maxGuesses:   maxGuesses,
+		thinkTime:    thinkTime,
+		wordLength:   game.DefaultWordLength,
+		mode:         game.Classic,
+		feedback:     game.WordleFeedback,
+		tier:         game.AnyTier,
+		language:     game.English,
+		boards:       1,
+		history:      newAnswerHistory(DefaultPlayerRepeatWindow, DefaultServerRepeatWindow),
+		queue:        make(chan *Player, 100),
+	}
+	for _, opt := range opts {
+		opt(lobby)
+	}
+	if lobby.mode == game.Fibble {
+		lobby.feedback = game.LyingFeedback
+	}
+	// Only randomly dealt words have a difficulty tier.
+	if lobby.mode != game.Classic && lobby.mode != game.Fibble {
+		lobby.tier = game.AnyTier
+	}
+	// Absurd boards would all dodge the same guesses the same way.
+	if lobby.mode == game.Absurd {
+		lobby.boards = 1
+	}
+	if lobby.source == nil {
+		lobby.source = rand.NewSource(time.Now().UnixNano())
+	}
+	lobby.rng = rand.New(lobby.source)
+	if lobby.mode == game.Nerdle {
+		// Equations are the same in every language.
+		lobby.language = game.English
+		lobby.wordLength = game.EquationLength
+		pool, err := game.NewEquationPool(lobby.wordLength)
+		if err != nil {
+			log.Fatal("Error generating equations:", err)
+		}
+		lobby.answers = pool
+	} else {
+		wordList, err := game.NewWordList(wordListPath, lobby.wordListOptions...)
+		if err != nil {
+			log.Printf("Error loading word list, using the built-in list: %v", err)
+			if wordList, err = game.DefaultWordList(lobby.wordListOptions...); err != nil {
+				log.Fatal("Error loading the built-in word list:", err)
+			}
+		}
+		lobby.answers = wordList
+		if lobby.reloadInterval > 0 {
+			lobby.watchWordList(lobby.reloadInterval)
+		}
+	}
+	if len(lobby.answers.Answers(lobby.wordLength)) < lobby.boards {
+		log.Fatalf("Need at least %d answers of length %d", lobby.boards, lobby.wordLength)
 	}
 	go lobby.startMatchingPlayer()
 	return lobby
 }
 
+// WithWordLength sets the number of letters in every answer.
+func WithWordLength(length int) LobbyOption {
+	return func(l *Lobby) {
+		l.wordLength = length
+	}
+}
+
+// WithMode sets the game variant played in every match.
+func WithMode(mode game.Mode) LobbyOption {
+	return func(l *Lobby) {
+		l.mode = mode
+	}
+}
+
+// WithBoards sets how many boards are played at once with a shared guess
+// budget.
+func WithBoards(boards int) LobbyOption {
+	return func(l *Lobby) {
+		l.boards = boards
+	}
+}
+
+// WithDailySecret sets the secret that keys the daily puzzle shuffle, so
+// only servers sharing the secret deal the same daily answers.
+func WithDailySecret(secret string) LobbyOption {
+	return func(l *Lobby) {
+		l.dailySecret = secret
+	}
+}
+
+// WithSource sets the random source that per-match seeds are drawn from, so
+// a lobby created with the same source plays the same sequence of matches.
+func WithSource(source rand.Source) LobbyOption {
+	return func(l *Lobby) {
+		l.source = source
+	}
+}
+
+// WithMatchSeed makes every match use seed, replaying the answers and turn
+// order of the match that logged it.
+func WithMatchSeed(seed int64) LobbyOption {
+	return func(l *Lobby) {
+		l.matchSeed = seed
+		l.fixedSeed = true
+	}
+}
+
+// WithAllowedGuesses accepts the words in path as guesses in addition to the
+// answers in the lobby's word list.
+func WithAllowedGuesses(path string) LobbyOption {
+	return func(l *Lobby) {
+		l.allowedGuessesPath = path
+		l.wordListOptions = append(l.wordListOptions, game.WithAllowedGuesses(path))
+	}
+}
+
+// WithBlocklist never deals the words in path as answers and masks them in
+// the typing and invalid word messages relayed to players.
+func WithBlocklist(path string) LobbyOption {
+	return func(l *Lobby) {
+		l.blocklistPath = path
+		l.wordListOptions = append(l.wordListOptions, game.WithBlocklist(path))
+	}
+}
+
+// WithHardMode enforces hard mode in every match. Hints revealed by either
+// player's guesses apply to both players, since they share the same board.
+func WithHardMode(enabled bool) LobbyOption {
+	return func(l *Lobby) {
+		l.hardMode = enabled
+	}
+}
+
+// WithFeedback sets how guesses are scored in every match.
+func WithFeedback(feedback game.Feedback) LobbyOption {
+	return func(l *Lobby) {
+		l.feedback = feedback
+	}
+}
+
+// WithTier deals answers from the given difficulty tier in classic and
+// fibble matches. Answers outside the tier are only dealt if it runs out.
+func WithTier(tier game.Tier) LobbyOption {
+	return func(l *Lobby) {
+		l.tier = tier
+	}
+}
+
+// WithReloadInterval checks the word list files for changes every interval
+// and reloads them when they change. Zero disables the check.
+func WithReloadInterval(interval time.Duration) LobbyOption {
+	return func(l *Lobby) {
+		l.reloadInterval = interval
+	}
+}
+
+// WithLanguage plays words of language. Guesses are normalized by its rules
+// before they are checked, and its word list should be passed to NewLobby.
+func WithLanguage(language *game.Language) LobbyOption {
+	return func(l *Lobby) {
+		l.language = language
+		l.wordListOptions = append(l.wordListOptions, game.WithLanguage(language))
+	}
+}
+
+// WithRepeatWindow keeps an answer from being dealt again to the same player
+// within their last playerWindow answers, or to anyone within the server's
+// last serverWindow answers. Answers only repeat sooner if the word list runs
+// out of fresh ones.
+func WithRepeatWindow(playerWindow, serverWindow int) LobbyOption {
+	return func(l *Lobby) {
+		l.history = newAnswerHistory(playerWindow, serverWindow)
+	}
+}
+
+// Variants lists what a client must support to play in the lobby.
+func (l *Lobby) Variants() []string {
+	variants := []string{string(l.mode), string(l.feedback), l.language.Code}
+	if l.boards > 1 {
+		variants = append(variants, VariantMultiBoard)
+	}
+	return variants
+}
+
+// WordLength returns the length of the lobby's answers.
+func (l *Lobby) WordLength() int {
+	return l.wordLength
+}
+
+// Boards returns the number of boards in every match.
+func (l *Lobby) Boards() int {
+	return l.boards
+}
+
+// WithRateLimit rejects messages beyond the first messages a player sends in
+// every interval.
+func WithRateLimit(messages int, interval time.Duration) LobbyOption {
+	return func(l *Lobby) {
+		l.rateLimit = messages
+		l.rateInterval = interval
+	}
+}
+
+// WithMaxStrikes disconnects a player after strikes rejected messages. Zero
+// never disconnects anyone.
+func WithMaxStrikes(strikes int) LobbyOption {
+	return func(l *Lobby) {
+		l.maxStrikes = strikes
+	}
+}
+
 func (l *Lobby) NewPlayer(client Client) *Player {
 	log.Printf("New player connected: %s", client.Nickname())
-	protocol := protocol.NewProtocol(PayloadRegistry)
 	player := &Player{
 		ID:       client.ID(),
 		Nickname: client.Nickname(),
-		incoming: protocol.UnwrapChannel(client.Incoming()),
-		outgoing: protocol.WrapChannel(client.Outgoing()),
 		error:    client.Error(),
+		client:   client,
+		done:     make(chan struct{}),
 	}
+	protocol := protocol.NewProtocol(PayloadRegistry,
+		protocol.WithErrorHandler(func(err *protocol.Error) {
+			l.reject(player, err)
+		}),
+		protocol.WithRateLimit(l.rateLimit, l.rateInterval),
+		protocol.WithCodec(client.Codec()),
+	)
+	// Rejections are sent back, so outgoing must exist before incoming.
+	player.outgoing = protocol.WrapChannel(client.Outgoing())
+	player.incoming = protocol.UnwrapChannel(client.Incoming())
 	// Welcome
 	player.outgoing <- &PlayerInfoPayload{
 		ID:       player.ID,
@@ -43,31 +251,98 @@ func (l *Lobby) NewPlayer(client Client) *Player {
 	return player
 }
 
+// reject tells player why their message was dropped and disconnects them once
+// they run out of strikes.
+func (l *Lobby) reject(player *Player, err *protocol.Error) {
+	if err.Outgoing {
+		log.Printf("Error sending %s to player %s: %v", err.Type, player.Nickname, err)
+		return
+	}
+	strikes := int(player.strikes.Add(1))
+	log.Printf("Rejected message from player %s (strike %d): %v", player.Nickname, strikes, err)
+	if !player.send(err.Payload()) {
+		return
+	}
+	if l.maxStrikes > 0 && strikes == l.maxStrikes {
+		log.Printf("Disconnecting player %s after %d rejected messages", player.Nickname, strikes)
+		player.client.Disconnect(fmt.Sprintf("too many rejected messages: %s", err.Code))
+	}
+}
+
 func (l *Lobby) RemovePlayer(player *Player) {
 	log.Printf("Removing player: %s", player.Nickname)
+	close(player.done)
+	// Wait for senders that found the player still connected.
+	player.sendMu.Lock()
+	defer player.sendMu.Unlock()
 	close(player.incoming)
 	close(player.outgoing)
 }
 
+// send delivers payload to player from outside the match loop, such as from
+// the protocol's error handler, and reports whether it was delivered. It
+// gives up once the player is removed instead of sending on a closed channel.
+func (p *Player) send(payload protocol.Payload) bool {
+	p.sendMu.RLock()
+	defer p.sendMu.RUnlock()
+	select {
+	case <-p.done:
+		return false
+	default:
+	}
+	select {
+	case p.outgoing <- payload:
+		return true
+	case <-p.done:
+		return false
+	}
+}
+
 func (l *Lobby) startGame(p1, p2 *Player) {
+	// The match keeps the answers it started with even if the word list is
+	// reloaded meanwhile.
+	answers := l.provider()
 	// Select random player to start
 	gameStartPayload := GameStartPayload{
 		MaxGuesses: l.maxGuesses,
+		WordLength: l.wordLength,
+		HardMode:   l.hardMode,
+		Mode:       l.mode,
+		Feedback:   l.feedback,
+		Tier:       l.tier,
+		Language:   l.language.Code,
+		Boards:     l.boards,
+	}
+	if l.mode == game.Daily {
+		gameStartPayload.Puzzle = game.DailyNumber(time.Now())
 	}
+	seed := l.nextMatchSeed()
+	log.Printf("Match between %s and %s uses seed %d", p1.Nickname, p2.Nickname, seed)
+	r := rand.New(rand.NewSource(seed))
 	// Player 1 goes first
-	if rand.Intn(2) == 0 {
+	if r.Intn(2) == 0 {
 		gameStartPayload.Player1 = p1
 		gameStartPayload.Player2 = p2
 	} else {
 		gameStartPayload.Player1 = p2
 		gameStartPayload.Player2 = p1
 	}
+	// Replayed matches must deal the same answers, so they ignore the history.
+	var recent map[string]bool
+	if !l.fixedSeed {
+		recent = l.history.Recent(p1.Nickname, p2.Nickname)
+	}
+	g := l.newGame(answers, r, recent)
+	if g == nil {
+		p1.client.Disconnect("daily puzzle unavailable")
+		p2.client.Disconnect("daily puzzle unavailable")
+		return
+	}
 	p1.outgoing <- &gameStartPayload
 	p2.outgoing <- &gameStartPayload
 
 	currentPlayer := gameStartPayload.Player1
-	g := game.NewGame(l.wordList.RandomWord(), gameStartPayload.MaxGuesses)
-	log.Printf("Game started with answer: %s", g.Answer)
+	log.Printf("Game started in %s mode with answers: %v", l.mode, g.Solutions())
 	round := 1
 	timeout := time.Duration(l.thinkTime) * time.Second
 	var winner *Player
@@ -85,7 +360,11 @@ func (l *Lobby) startGame(p1, p2 *Player) {
 
 	roundTimer := sendRoundStart(currentPlayer, round)
 
-	for round <= l.maxGuesses && g.State == game.InProgress && winner == nil {
+	for round <= l.maxGuesses && g.Status() == game.InProgress && winner == nil {
+		waitingPlayer := p2
+		if currentPlayer == p2 {
+			waitingPlayer = p1
+		}
 		select {
 		case p1Err := <-p1.error:
 			log.Println("Error from player 1:", p1Err)
@@ -111,11 +390,21 @@ func (l *Lobby) startGame(p1, p2 *Player) {
 				}
 				roundTimer = sendRoundStart(currentPlayer, round)
 			}
+		case rawMsg := <-waitingPlayer.incoming:
+			// Typing out of turn is harmless, so it is dropped without a strike.
+			if _, typing := rawMsg.(*TypingPayload); rawMsg != nil && !typing {
+				l.reject(waitingPlayer, &protocol.Error{
+					Code: protocol.CodeOutOfTurn,
+					Type: rawMsg.MessageType(),
+					Err:  fmt.Errorf("it is %s's turn", currentPlayer.Nickname),
+				})
+			}
 		case rawMsg := <-currentPlayer.incoming:
 			switch msg := rawMsg.(type) {
 			case *TypingPayload:
 				log.Printf("Player %s is typing: %s", currentPlayer.Nickname, msg.Word)
 				// Send to the other player
+				msg.Word = censor(answers, msg.Word)
 				if currentPlayer == p1 {
 					msg.Player = p1
 					p2.outgoing <- msg
@@ -125,33 +414,57 @@ func (l *Lobby) startGame(p1, p2 *Player) {
 				}
 			case *GuessPayload:
 				// Handle guess
+				msg.Word = l.language.Normalize(msg.Word)
 				log.Printf("Player %s guessed: %s", currentPlayer.Nickname, msg.Word)
 				// Validate the word
-				if !l.wordList.IsValidWord(msg.Word) {
-					log.Printf("Invalid word guessed")
+				if err := answers.CheckGuess(msg.Word); err != nil {
+					log.Printf("Invalid word guessed: %v", err)
 					var invalidWordPayload InvalidWordPayload
 					invalidWordPayload.Player = currentPlayer
 					invalidWordPayload.Round = round
-					invalidWordPayload.Word = msg.Word
+					invalidWordPayload.Word = censor(answers, msg.Word)
+					if l.mode == game.Nerdle {
+						invalidWordPayload.Reason = err.Error()
+					}
 					p1.outgoing <- &invalidWordPayload
 					p2.outgoing <- &invalidWordPayload
 					continue
 				}
 				// Process the guess
-				result, _ := g.MakeGuess(msg.Word)
-				if g.State == game.Won {
+				results, err := g.MakeGuess(msg.Word)
+				if err != nil {
+					log.Printf("Guess rejected: %v", err)
+					var invalidWordPayload InvalidWordPayload
+					invalidWordPayload.Player = currentPlayer
+					invalidWordPayload.Round = round
+					invalidWordPayload.Word = censor(answers, msg.Word)
+					invalidWordPayload.Reason = err.Error()
+					p1.outgoing <- &invalidWordPayload
+					p2.outgoing <- &invalidWordPayload
+					continue
+				}
+				if g.Status() == game.Won {
 					winner = currentPlayer
 				}
-				// Send the feedback to both players
-				var feedbackPayload FeedbackPayload
-				feedbackPayload.Player = currentPlayer
-				feedbackPayload.Round = round
-				feedbackPayload.Feedback = result
-				p1.outgoing <- &feedbackPayload
-				p2.outgoing <- &feedbackPayload
+				// Send the feedback for each unsolved board to both players
+				keyboards := g.Keyboards()
+				for board, result := range results {
+					if result == nil {
+						continue
+					}
+					var feedbackPayload FeedbackPayload
+					feedbackPayload.Player = currentPlayer
+					feedbackPayload.Round = round
+					feedbackPayload.Board = board
+					feedbackPayload.Word = msg.Word
+					feedbackPayload.Feedback = game.Conceal(result)
+					feedbackPayload.Keyboard = keyboards[board]
+					p1.outgoing <- &feedbackPayload
+					p2.outgoing <- &feedbackPayload
+				}
 				// Swap players and increment round
 				round++
-				if round <= l.maxGuesses && winner == nil && g.State == game.InProgress {
+				if round <= l.maxGuesses && winner == nil && g.Status() == game.InProgress {
 					if currentPlayer == p1 {
 						currentPlayer = p2
 					} else {
@@ -164,21 +477,87 @@ func (l *Lobby) startGame(p1, p2 *Player) {
 	}
 	// Game over
 	var gameOverPayload GameOverPayload
+	solutions := g.Solutions()
+	l.history.Add(solutions, p1.Nickname, p2.Nickname)
 	if winner != nil {
 		log.Printf("Player %s wins!", winner.Nickname)
 		gameOverPayload.Winner = winner
-		gameOverPayload.Answer = g.Answer
 	} else {
 		log.Printf("Game ended in a draw")
 		gameOverPayload.Winner = nil
-		gameOverPayload.Answer = g.Answer
 	}
+	gameOverPayload.Answer = solutions[0]
+	if len(solutions) > 1 {
+		gameOverPayload.Answers = solutions
+	}
+	gameOverPayload.Lies = g.Lies()
+	grid := share.FromMultiGame(g, l.maxGuesses, l.hardMode)
+	grid.Puzzle = gameStartPayload.Puzzle
+	if l.mode == game.Nerdle {
+		grid.Title = "Nerdle"
+	}
+	gameOverPayload.Share = grid.String()
 	p1.outgoing <- &gameOverPayload
 	p2.outgoing <- &gameOverPayload
 	go l.checkPlayAgain(p1)
 	go l.checkPlayAgain(p2)
 }
 
+// censor masks text if it spells a word on the blocklist of answers.
+func censor(answers game.AnswerProvider, text string) string {
+	if wordList, ok := answers.(*game.WordList); ok {
+		return wordList.Censor(text)
+	}
+	return text
+}
+
+// nextMatchSeed returns the seed for the next match.
+func (l *Lobby) nextMatchSeed() int64 {
+	if l.fixedSeed {
+		return l.matchSeed
+	}
+	l.rngMu.Lock()
+	defer l.rngMu.Unlock()
+	return l.rng.Int63()
+}
+
+// newGame creates the boards for the lobby's mode, drawing answers and lies
+// from r and avoiding the recent answers if possible. Both players guess on
+// the same boards. It returns nil if the daily puzzle cannot be dealt.
+func (l *Lobby) newGame(answers game.AnswerProvider, r *rand.Rand, recent map[string]bool) *game.MultiGame {
+	wordList, _ := answers.(*game.WordList)
+	boards := make([]game.Playable, l.boards)
+	opts := func() []game.Option {
+		return []game.Option{
+			game.WithHardMode(l.hardMode),
+			game.WithEvaluator(l.feedback.Evaluator(r)),
+		}
+	}
+	switch l.mode {
+	case game.Absurd:
+		for i := range boards {
+			boards[i] = game.NewAbsurdGame(answers.Answers(l.wordLength), l.maxGuesses, opts()...)
+		}
+	case game.Daily:
+		words, err := wordList.DailyWords(time.Now(), l.dailySecret, l.wordLength, l.boards)
+		if err != nil {
+			log.Printf("Cannot deal the daily puzzle: %v", err)
+			return nil
+		}
+		for i, answer := range words {
+			boards[i] = game.NewGame(answer, l.maxGuesses, opts()...)
+		}
+	default:
+		exclude := func(answer string) bool {
+			return recent[answer] || wordList != nil && !wordList.InTier(answer, l.tier)
+		}
+		for i, answer := range answers.RandomWordsFrom(r, l.wordLength, l.boards, exclude) {
+			boards[i] = game.NewGame(answer, l.maxGuesses, opts()...)
+		}
+	}
+	return game.NewMultiGame(boards...)
+}
+
 func (l *Lobby) checkPlayAgain(player *Player) bool {
 	rawMsg := <-player.incoming
 	switch msg := rawMsg.(type) {
@@ -2,20 +2,35 @@ package multiplayer
 
 import (
 	"encoding/json"
+	"errors"
+	"math/rand"
 	"path"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/tomlaws/wordle/internal/game"
 	"github.com/tomlaws/wordle/internal/protocol"
 	"github.com/tomlaws/wordle/pkg/utils"
 )
 
 type MockClient struct {
-	id       func() string
-	nickname func() string
-	incoming func() chan json.RawMessage
-	outgoing func() chan json.RawMessage
-	error    func() chan error
+	id         func() string
+	nickname   func() string
+	incoming   func() chan []byte
+	outgoing   func() chan []byte
+	error      func() chan error
+	disconnect func(reason string)
+}
+
+func (m *MockClient) Codec() protocol.Codec {
+	return protocol.JSON
+}
+
+func (m *MockClient) Disconnect(reason string) {
+	if m.disconnect != nil {
+		m.disconnect(reason)
+	}
 }
 
 func (m *MockClient) ID() string {
@@ -26,11 +41,11 @@ func (m *MockClient) Nickname() string {
 	return m.nickname()
 }
 
-func (m *MockClient) Incoming() chan json.RawMessage {
+func (m *MockClient) Incoming() chan []byte {
 	return m.incoming()
 }
 
-func (m *MockClient) Outgoing() chan json.RawMessage {
+func (m *MockClient) Outgoing() chan []byte {
 	return m.outgoing()
 }
 
@@ -40,12 +55,12 @@ func (m *MockClient) Error() chan error {
 
 func TestLobby_NewPlayer(t *testing.T) {
 	lobby := NewLobby(path.Join(utils.Root, "assets", "words.txt"), 6, 30)
-	outgoing := make(chan json.RawMessage)
+	outgoing := make(chan []byte)
 	mockClient := MockClient{
 		id:       func() string { return "player1" },
 		nickname: func() string { return "Player One" },
-		incoming: func() chan json.RawMessage { return make(chan json.RawMessage) },
-		outgoing: func() chan json.RawMessage { return outgoing },
+		incoming: func() chan []byte { return make(chan []byte) },
+		outgoing: func() chan []byte { return outgoing },
 		error:    func() chan error { return make(chan error) },
 	}
 	go func() {
@@ -70,12 +85,12 @@ func TestLobby_NewPlayer(t *testing.T) {
 
 func TestLobby_RemovePlayer(t *testing.T) {
 	lobby := NewLobby(path.Join(utils.Root, "assets", "words.txt"), 6, 30)
-	outgoing := make(chan json.RawMessage)
+	outgoing := make(chan []byte)
 	mockClient := MockClient{
 		id:       func() string { return "player1" },
 		nickname: func() string { return "Player One" },
-		incoming: func() chan json.RawMessage { return make(chan json.RawMessage) },
-		outgoing: func() chan json.RawMessage { return outgoing },
+		incoming: func() chan []byte { return make(chan []byte) },
+		outgoing: func() chan []byte { return outgoing },
 		error:    func() chan error { return make(chan error) },
 	}
 	go func() {
@@ -95,12 +110,12 @@ func TestLobby_RemovePlayer(t *testing.T) {
 
 func TestLobby_AddPlayerToQueue(t *testing.T) {
 	lobby := NewLobby(path.Join(utils.Root, "assets", "words.txt"), 6, 30)
-	outgoing1 := make(chan json.RawMessage)
+	outgoing1 := make(chan []byte)
 	mockClient1 := MockClient{
 		id:       func() string { return "player1" },
 		nickname: func() string { return "Player One" },
-		incoming: func() chan json.RawMessage { return make(chan json.RawMessage) },
-		outgoing: func() chan json.RawMessage { return outgoing1 },
+		incoming: func() chan []byte { return make(chan []byte) },
+		outgoing: func() chan []byte { return outgoing1 },
 		error:    func() chan error { return make(chan error) },
 	}
 	go func() {
@@ -110,12 +125,12 @@ func TestLobby_AddPlayerToQueue(t *testing.T) {
 	if len(lobby.queue) != 1 {
 		t.Errorf("Expected queue length 1 after adding first player, got %d", len(lobby.queue))
 	}
-	outgoing2 := make(chan json.RawMessage)
+	outgoing2 := make(chan []byte)
 	mockClient2 := MockClient{
 		id:       func() string { return "player2" },
 		nickname: func() string { return "Player Two" },
-		incoming: func() chan json.RawMessage { return make(chan json.RawMessage) },
-		outgoing: func() chan json.RawMessage { return outgoing2 },
+		incoming: func() chan []byte { return make(chan []byte) },
+		outgoing: func() chan []byte { return outgoing2 },
 		error:    func() chan error { return make(chan error) },
 	}
 	go func() {
@@ -129,13 +144,13 @@ func TestLobby_AddPlayerToQueue(t *testing.T) {
 
 func TestLobby_SkipDisconnectedPlayer(t *testing.T) {
 	lobby := NewLobby(path.Join(utils.Root, "assets", "words.txt"), 6, 5)
-	outgoing1 := make(chan json.RawMessage)
+	outgoing1 := make(chan []byte)
 	error1 := make(chan error, 1)
 	mockClient1 := MockClient{
 		id:       func() string { return "player1" },
 		nickname: func() string { return "Player One" },
-		incoming: func() chan json.RawMessage { return make(chan json.RawMessage) },
-		outgoing: func() chan json.RawMessage { return outgoing1 },
+		incoming: func() chan []byte { return make(chan []byte) },
+		outgoing: func() chan []byte { return outgoing1 },
 		error:    func() chan error { return error1 },
 	}
 	go func() {
@@ -145,12 +160,12 @@ func TestLobby_SkipDisconnectedPlayer(t *testing.T) {
 	if len(lobby.queue) != 1 {
 		t.Errorf("Expected queue length 1 after adding first player, got %d", len(lobby.queue))
 	}
-	outgoing2 := make(chan json.RawMessage)
+	outgoing2 := make(chan []byte)
 	mockClient2 := MockClient{
 		id:       func() string { return "player2" },
 		nickname: func() string { return "Player Two" },
-		incoming: func() chan json.RawMessage { return make(chan json.RawMessage) },
-		outgoing: func() chan json.RawMessage { return outgoing2 },
+		incoming: func() chan []byte { return make(chan []byte) },
+		outgoing: func() chan []byte { return outgoing2 },
 		error:    func() chan error { return make(chan error) },
 	}
 	go func() {
@@ -168,3 +183,181 @@ func TestLobby_SkipDisconnectedPlayer(t *testing.T) {
 		t.Errorf("Expected queue length 1 after player 1 disconnected, got %d", len(lobby.queue))
 	}
 }
+
+func TestLobby_MatchSeedIsReproducible(t *testing.T) {
+	wordListPath := path.Join(utils.Root, "assets", "words.txt")
+	first := NewLobby(wordListPath, 6, 30, WithSource(rand.NewSource(1)))
+	second := NewLobby(wordListPath, 6, 30, WithSource(rand.NewSource(1)))
+	for i := 0; i < 3; i++ {
+		seed := first.nextMatchSeed()
+		if other := second.nextMatchSeed(); seed != other {
+			t.Fatalf("Expected the same match seeds, got %d and %d", seed, other)
+		}
+		a := first.newGame(first.provider(), rand.New(rand.NewSource(seed)), nil).Solutions()
+		b := second.newGame(second.provider(), rand.New(rand.NewSource(seed)), nil).Solutions()
+		if a[0] != b[0] {
+			t.Errorf("Expected the same answer for seed %d, got %s and %s", seed, a[0], b[0])
+		}
+	}
+}
+
+func TestLobby_WithMatchSeed(t *testing.T) {
+	lobby := NewLobby(path.Join(utils.Root, "assets", "words.txt"), 6, 30, WithMatchSeed(42))
+	if seed := lobby.nextMatchSeed(); seed != 42 {
+		t.Errorf("Expected fixed match seed 42, got %d", seed)
+	}
+	if seed := lobby.nextMatchSeed(); seed != 42 {
+		t.Errorf("Expected fixed match seed 42 for every match, got %d", seed)
+	}
+}
+
+func TestLobby_NewGameAvoidsRecentAnswers(t *testing.T) {
+	lobby := NewLobby(path.Join(utils.Root, "assets", "words.txt"), 6, 30, WithSource(rand.NewSource(1)))
+	answers := lobby.WordList().Answers(lobby.wordLength)
+	recent := make(map[string]bool)
+	for _, answer := range answers[1:] {
+		recent[answer] = true
+	}
+	for seed := int64(0); seed < 5; seed++ {
+		if got := lobby.newGame(lobby.provider(), rand.New(rand.NewSource(seed)), recent).Solutions()[0]; got != answers[0] {
+			t.Errorf("Expected the only fresh answer %s, got %s", answers[0], got)
+		}
+	}
+	recent[answers[0]] = true
+	if got := lobby.newGame(lobby.provider(), rand.New(rand.NewSource(1)), recent).Solutions(); len(got) != 1 || got[0] == "" {
+		t.Errorf("Expected an answer even when every answer is recent, got %v", got)
+	}
+}
+
+func TestLobby_NewGameUsesTier(t *testing.T) {
+	for _, tier := range []game.Tier{game.EasyTier, game.HardTier} {
+		lobby := NewLobby(path.Join(utils.Root, "assets", "words.txt"), 6, 30, WithTier(tier), WithBoards(2))
+		for seed := int64(0); seed < 10; seed++ {
+			for _, answer := range lobby.newGame(lobby.provider(), rand.New(rand.NewSource(seed)), nil).Solutions() {
+				if got := lobby.WordList().Tier(answer); got != tier {
+					t.Errorf("Expected a %s answer, got %s which is %s", tier, answer, got)
+				}
+			}
+		}
+	}
+	lobby := NewLobby(path.Join(utils.Root, "assets", "words.txt"), 6, 30, WithTier(game.HardTier), WithMode(game.Absurd))
+	if lobby.tier != game.AnyTier {
+		t.Errorf("Expected the tier to be ignored in absurd mode, got %s", lobby.tier)
+	}
+}
+
+func TestLobby_BlocklistIsCensored(t *testing.T) {
+	dir := t.TempDir()
+	wordListPath := path.Join(dir, "words.txt")
+	blocklistPath := path.Join(dir, "blocklist.txt")
+	writeWordList(t, wordListPath, "apple\nbeach\n")
+	writeWordList(t, blocklistPath, "beach\n")
+	lobby := NewLobby(wordListPath, 6, 30, WithBlocklist(blocklistPath))
+	answers := lobby.provider()
+	if got := lobby.newGame(answers, rand.New(rand.NewSource(1)), nil).Solutions(); got[0] != "apple" {
+		t.Errorf("Expected a blocked word to never be dealt, got %v", got)
+	}
+	if answers.CheckGuess("beach") != nil {
+		t.Error("Expected a blocked word to stay a valid guess")
+	}
+	if got := censor(answers, "beach"); got != "*****" {
+		t.Errorf("Expected a blocked word to be masked, got %s", got)
+	}
+	if got := censor(answers, "apple"); got != "apple" {
+		t.Errorf("Expected other words to be relayed as is, got %s", got)
+	}
+}
+
+func TestLobby_WithLanguage(t *testing.T) {
+	dir := t.TempDir()
+	wordListPath := path.Join(dir, "words.txt")
+	writeWordList(t, wordListPath, "niños\nÁrbol\n")
+	lobby := NewLobby(wordListPath, 6, 30, WithLanguage(game.Spanish))
+	answers := lobby.provider()
+	for _, word := range []string{"niños", "arbol"} {
+		if err := answers.CheckGuess(word); err != nil {
+			t.Errorf("Expected %s to be a five-letter guess, got %v", word, err)
+		}
+	}
+	if variants := lobby.Variants(); !slices.Contains(variants, "es") {
+		t.Errorf("Expected the handshake to require Spanish, got %v", variants)
+	}
+	lobby = NewLobby(wordListPath, 6, 30, WithLanguage(game.Spanish), WithMode(game.Nerdle))
+	if lobby.language != game.English {
+		t.Errorf("Expected nerdle to play in English, got %s", lobby.language.Code)
+	}
+}
+
+func TestLobby_RejectsBadMessages(t *testing.T) {
+	lobby := NewLobby(path.Join(utils.Root, "assets", "words.txt"), 6, 30, WithMaxStrikes(2))
+	incoming := make(chan []byte)
+	outgoing := make(chan []byte)
+	disconnected := make(chan string, 1)
+	mockClient := MockClient{
+		id:         func() string { return "player1" },
+		nickname:   func() string { return "Player One" },
+		incoming:   func() chan []byte { return incoming },
+		outgoing:   func() chan []byte { return outgoing },
+		error:      func() chan error { return make(chan error) },
+		disconnect: func(reason string) { disconnected <- reason },
+	}
+	go lobby.NewPlayer(&mockClient)
+	<-outgoing // player_info
+	<-outgoing // matching
+	receive := func() protocol.ErrorPayload {
+		var message protocol.Message
+		if err := json.Unmarshal(<-outgoing, &message); err != nil {
+			t.Fatalf("Failed to unmarshal Message: %v", err)
+		}
+		if message.Type != protocol.MsgTypeError {
+			t.Fatalf("Expected an error message, got %s", message.Type)
+		}
+		var payload protocol.ErrorPayload
+		json.Unmarshal(message.Payload, &payload)
+		return payload
+	}
+	incoming <- []byte(`{"type":"dance","payload":{}}`)
+	if got := receive(); got.Code != protocol.CodeUnknownType || got.Rejected != "dance" {
+		t.Errorf("Expected an unknown_type error for dance, got %+v", got)
+	}
+	select {
+	case <-disconnected:
+		t.Fatal("Expected the first strike not to disconnect")
+	default:
+	}
+	incoming <- []byte(`{"type":"guess","payload":{"word":5}}`)
+	if got := receive(); got.Code != protocol.CodeBadPayload {
+		t.Errorf("Expected a bad_payload error, got %+v", got)
+	}
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second):
+		t.Error("Expected the player to be disconnected after two strikes")
+	}
+}
+
+func TestLobby_RejectAfterRemovePlayer(t *testing.T) {
+	lobby := NewLobby(path.Join(utils.Root, "assets", "words.txt"), 6, 30, WithMaxStrikes(1))
+	outgoing := make(chan []byte)
+	disconnected := make(chan string, 1)
+	mockClient := MockClient{
+		id:         func() string { return "player1" },
+		nickname:   func() string { return "Player One" },
+		incoming:   func() chan []byte { return make(chan []byte) },
+		outgoing:   func() chan []byte { return outgoing },
+		error:      func() chan error { return make(chan error) },
+		disconnect: func(reason string) { disconnected <- reason },
+	}
+	go func() {
+		<-outgoing // player_info
+		<-outgoing // matching
+	}()
+	player := lobby.NewPlayer(&mockClient)
+	lobby.RemovePlayer(player)
+	lobby.reject(player, &protocol.Error{Code: protocol.CodeOutOfTurn, Type: MsgTypeGuess, Err: errors.New("it is Player Two's turn")})
+	select {
+	case <-disconnected:
+		t.Error("Expected a removed player not to be disconnected again")
+	default:
+	}
+}
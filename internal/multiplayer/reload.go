@@ -0,0 +1,86 @@
+package multiplayer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tomlaws/wordle/internal/game"
+)
+
+// provider returns the answers dealt in matches that start now.
+func (l *Lobby) provider() game.AnswerProvider {
+	l.listMu.RLock()
+	defer l.listMu.RUnlock()
+	return l.answers
+}
+
+// WordList returns the word list dealt in matches that start now, or nil in
+// nerdle mode.
+func (l *Lobby) WordList() *game.WordList {
+	wordList, _ := l.provider().(*game.WordList)
+	return wordList
+}
+
+// Reload loads the word list files again and deals from them in the matches
+// that start from now on. Matches in progress keep the list they started
+// with. The current list is kept if the files cannot be loaded or have too
+// few answers. Reload does nothing in nerdle mode.
+func (l *Lobby) Reload() error {
+	if l.mode == game.Nerdle {
+		return nil
+	}
+	wordList, err := game.NewWordList(l.wordListPath, l.wordListOptions...)
+	if err != nil {
+		return err
+	}
+	if count := len(wordList.Answers(l.wordLength)); count < l.boards {
+		return fmt.Errorf("need at least %d answers of length %d, got %d", l.boards, l.wordLength, count)
+	}
+	l.listMu.Lock()
+	l.answers = wordList
+	l.listMu.Unlock()
+	log.Printf("Reloaded word list %s", l.wordListPath)
+	return nil
+}
+
+// watchWordList starts reloading the word list whenever one of its files
+// changes from now on, checking every interval.
+func (l *Lobby) watchWordList(interval time.Duration) {
+	paths := []string{l.wordListPath}
+	for _, path := range []string{l.allowedGuessesPath, l.blocklistPath} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	last := fileStamps(paths)
+	go func() {
+		for range time.Tick(interval) {
+			stamps := fileStamps(paths)
+			if stamps == last {
+				continue
+			}
+			last = stamps
+			if err := l.Reload(); err != nil {
+				log.Printf("Error reloading word list: %v", err)
+			}
+		}
+	}()
+}
+
+// fileStamps describes the size and modification time of every path, so it
+// changes whenever one of the files is written, replaced or removed.
+func fileStamps(paths []string) string {
+	var stamps strings.Builder
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			fmt.Fprintf(&stamps, "%s missing\n", path)
+			continue
+		}
+		fmt.Fprintf(&stamps, "%s %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+	}
+	return stamps.String()
+}
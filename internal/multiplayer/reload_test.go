@@ -0,0 +1,68 @@
+package multiplayer
+
+import (
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func writeWordList(t *testing.T, wordListPath, content string) {
+	t.Helper()
+	if err := os.WriteFile(wordListPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write word list: %v", err)
+	}
+}
+
+func TestLobby_Reload(t *testing.T) {
+	wordListPath := path.Join(t.TempDir(), "words.txt")
+	writeWordList(t, wordListPath, "apple\n")
+	lobby := NewLobby(wordListPath, 6, 30)
+	inFlight := lobby.provider()
+	g := lobby.newGame(inFlight, rand.New(rand.NewSource(1)), nil)
+
+	writeWordList(t, wordListPath, "beach\n")
+	if err := lobby.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if answers := lobby.WordList().Answers(5); len(answers) != 1 || answers[0] != "beach" {
+		t.Errorf("Expected new matches to deal the reloaded list, got %v", answers)
+	}
+	if g.Solutions()[0] != "apple" || inFlight.CheckGuess("apple") != nil {
+		t.Error("Expected the match in progress to keep its word list")
+	}
+
+	writeWordList(t, wordListPath, "beach 0\n")
+	if err := lobby.Reload(); err == nil {
+		t.Error("Expected an invalid word list to be rejected")
+	}
+	writeWordList(t, wordListPath, "sixsix\n")
+	if err := lobby.Reload(); err == nil {
+		t.Error("Expected a word list without answers of the lobby's length to be rejected")
+	}
+	if answers := lobby.WordList().Answers(5); len(answers) != 1 || answers[0] != "beach" {
+		t.Errorf("Expected a failed reload to keep the current list, got %v", answers)
+	}
+}
+
+func TestLobby_ReloadsOnFileChange(t *testing.T) {
+	wordListPath := path.Join(t.TempDir(), "words.txt")
+	writeWordList(t, wordListPath, "apple\n")
+	lobby := NewLobby(wordListPath, 6, 30, WithReloadInterval(10*time.Millisecond))
+	writeWordList(t, wordListPath, "apple\nbeach\n")
+	deadline := time.Now().Add(2 * time.Second)
+	for len(lobby.WordList().Answers(5)) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the changed word list to be reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestLobby_MissingWordListFallsBack(t *testing.T) {
+	lobby := NewLobby(path.Join(t.TempDir(), "missing.txt"), 6, 30)
+	if len(lobby.WordList().Answers(5)) == 0 {
+		t.Error("Expected the built-in word list to be used")
+	}
+}
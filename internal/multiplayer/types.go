@@ -1,7 +1,9 @@
 package multiplayer
 
 import (
-	"encoding/json"
+	"math/rand"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tomlaws/wordle/internal/game"
@@ -11,9 +13,13 @@ import (
 type Client interface {
 	ID() string
 	Nickname() string
-	Incoming() chan json.RawMessage
-	Outgoing() chan json.RawMessage
+	Incoming() chan []byte
+	Outgoing() chan []byte
 	Error() chan error
+	// Codec encodes the client's messages.
+	Codec() protocol.Codec
+	// Disconnect closes the connection, telling the client why.
+	Disconnect(reason string)
 }
 
 type Player struct {
@@ -22,13 +28,57 @@ type Player struct {
 	incoming chan protocol.Payload
 	outgoing chan protocol.Payload
 	error    chan error
+	client   Client
+	strikes  atomic.Int32
+	// done is closed by RemovePlayer. sendMu keeps outgoing open while send
+	// is using it.
+	done   chan struct{}
+	sendMu sync.RWMutex
 }
 
 type Lobby struct {
-	wordList   *game.WordList
-	maxGuesses int
-	thinkTime  int
-	queue      chan *Player
+	// answers is an EquationPool in Nerdle mode and a WordList otherwise.
+	// Reload replaces it; listMu guards it.
+	answers            game.AnswerProvider
+	listMu             sync.RWMutex
+	wordListPath       string
+	allowedGuessesPath string
+	blocklistPath      string
+	wordListOptions    []game.WordListOption
+	reloadInterval     time.Duration
+	maxGuesses         int
+	thinkTime          int
+	wordLength         int
+	mode               game.Mode
+	boards             int
+	dailySecret        string
+	hardMode           bool
+	feedback           game.Feedback
+	tier               game.Tier
+	language           *game.Language
+	source             rand.Source
+	rng                *rand.Rand
+	rngMu              sync.Mutex
+	matchSeed          int64
+	fixedSeed          bool
+	history            *answerHistory
+	rateLimit          int
+	rateInterval       time.Duration
+	maxStrikes         int
+	queue              chan *Player
+}
+
+// LobbyOption configures a Lobby created by NewLobby.
+type LobbyOption func(*Lobby)
+
+// answerHistory remembers the answers dealt most recently, server-wide and to
+// each player by nickname, so they are not dealt again within a window.
+type answerHistory struct {
+	playerWindow int
+	serverWindow int
+	server       []string
+	players      map[string][]string
+	mu           sync.Mutex
 }
 
 const (
@@ -48,7 +98,20 @@ const (
 	MsgTypeGameOver     protocol.MessageType = "game_over"
 )
 
+// VariantMultiBoard is the handshake variant for games with several boards.
+// Modes, feedback styles and languages are variants by their own names or
+// codes.
+const VariantMultiBoard = "multiboard"
+
+// PayloadRegistry maps every message type to its payload. The web client's
+// payload types and the protocol's JSON Schema are generated from it.
+//
+//go:generate go run ../../cmd/payloadgen -ts ../../web/src/lib/types/payload.ts -schema ../../docs/protocol.schema.json
 var PayloadRegistry = map[protocol.MessageType]func() protocol.Payload{
+	protocol.MsgTypeHello:   func() protocol.Payload { return &protocol.HelloPayload{} },
+	protocol.MsgTypeWelcome: func() protocol.Payload { return &protocol.WelcomePayload{} },
+	protocol.MsgTypeError:   func() protocol.Payload { return &protocol.ErrorPayload{} },
+
 	MsgTypePlayerInfo:   func() protocol.Payload { return &PlayerInfoPayload{} },
 	MsgTypeMatching:     func() protocol.Payload { return &MatchingPayload{} },
 	MsgTypeGameStart:    func() protocol.Payload { return &GameStartPayload{} },
@@ -80,9 +143,17 @@ func (p *MatchingPayload) MessageType() protocol.MessageType {
 }
 
 type GameStartPayload struct {
-	MaxGuesses int     `json:"max_guesses"`
-	Player1    *Player `json:"player1"`
-	Player2    *Player `json:"player2"`
+	MaxGuesses int           `json:"max_guesses"`
+	WordLength int           `json:"word_length"`
+	HardMode   bool          `json:"hard_mode"`
+	Mode       game.Mode     `json:"mode"`
+	Feedback   game.Feedback `json:"feedback"`
+	Tier       game.Tier     `json:"tier,omitempty"`
+	Language   string        `json:"language"`
+	Boards     int           `json:"boards"`
+	Puzzle     int           `json:"puzzle,omitempty"`
+	Player1    *Player       `json:"player1"`
+	Player2    *Player       `json:"player2"`
 }
 
 func (p *GameStartPayload) MessageType() protocol.MessageType {
@@ -103,6 +174,7 @@ type InvalidWordPayload struct {
 	Player *Player `json:"player"`
 	Round  int     `json:"round"`
 	Word   string  `json:"word"`
+	Reason string  `json:"reason,omitempty"`
 }
 
 func (p *InvalidWordPayload) MessageType() protocol.MessageType {
@@ -118,10 +190,16 @@ func (p *GuessTimeoutPayload) MessageType() protocol.MessageType {
 	return MsgTypeGuessTimeout
 }
 
+// FeedbackPayload carries the result of a guess on one board. Multi-board
+// matches send one payload per unsolved board.
 type FeedbackPayload struct {
 	Player   *Player             `json:"player"`
 	Round    int                 `json:"round"`
+	Board    int                 `json:"board"`
+	Word     string              `json:"word"`
 	Feedback []game.LetterResult `json:"feedback"`
+	// Keyboard is the best-known state of every letter guessed on the board.
+	Keyboard game.Keyboard `json:"keyboard,omitempty"`
 }
 
 func (p *FeedbackPayload) MessageType() protocol.MessageType {
@@ -131,6 +209,12 @@ func (p *FeedbackPayload) MessageType() protocol.MessageType {
 type GameOverPayload struct {
 	Winner *Player `json:"winner"`
 	Answer string  `json:"answer"`
+	// Answers lists the answer of every board in multi-board matches.
+	Answers []string `json:"answers,omitempty"`
+	// Lies reveals the falsified tiles of a match with lying feedback.
+	Lies []game.Lie `json:"lies,omitempty"`
+	// Share is the emoji grid of the match, ready to paste.
+	Share string `json:"share,omitempty"`
 }
 
 func (p *GameOverPayload) MessageType() protocol.MessageType {
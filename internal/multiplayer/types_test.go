@@ -0,0 +1,44 @@
+package multiplayer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tomlaws/wordle/internal/game"
+	"github.com/tomlaws/wordle/internal/protocol"
+)
+
+func TestPayloadRegistry_Codecs(t *testing.T) {
+	player := &Player{ID: "player1", Nickname: "Player One"}
+	payloads := []protocol.Payload{
+		&GameStartPayload{MaxGuesses: 6, WordLength: 5, Mode: game.Classic, Feedback: game.WordleFeedback, Language: "en", Boards: 1, Player1: player, Player2: player},
+		&RoundStartPayload{Player: player, Round: 2, Deadline: time.Now().UTC().Round(time.Microsecond)},
+		&FeedbackPayload{Player: player, Round: 1, Word: "apple", Feedback: game.Conceal(game.ScoreMastermind("crane", "apple"))},
+		&GameOverPayload{Winner: player, Answer: "apple", Answers: []string{"apple", "beach"}},
+		&protocol.ErrorPayload{Code: protocol.CodeOutOfTurn, Rejected: MsgTypeGuess, Message: "not your turn"},
+	}
+	for _, codec := range protocol.Codecs {
+		for _, sent := range payloads {
+			data, err := codec.Encode(sent)
+			if err != nil {
+				t.Fatalf("%s: encode %s failed: %v", codec.Name(), sent.MessageType(), err)
+			}
+			msgType, raw, err := codec.Decode(data)
+			if err != nil {
+				t.Fatalf("%s: decode %s failed: %v", codec.Name(), sent.MessageType(), err)
+			}
+			received := PayloadRegistry[msgType]()
+			if err := codec.Unmarshal(raw, received); err != nil {
+				t.Fatalf("%s: unmarshal %s failed: %v", codec.Name(), msgType, err)
+			}
+			// MessagePack decodes times in the local time zone.
+			if roundStart, ok := received.(*RoundStartPayload); ok && roundStart.Deadline.Equal(sent.(*RoundStartPayload).Deadline) {
+				roundStart.Deadline = sent.(*RoundStartPayload).Deadline
+			}
+			if !reflect.DeepEqual(received, sent) {
+				t.Errorf("%s: expected %+v, got %+v", codec.Name(), sent, received)
+			}
+		}
+	}
+}
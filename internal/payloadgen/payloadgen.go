@@ -0,0 +1,135 @@
+// Package payloadgen generates the web client's payload types and the
+// protocol's JSON Schema from a payload registry and the payloads' json tags.
+package payloadgen
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tomlaws/wordle/internal/protocol"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func New(registry map[protocol.MessageType]func() protocol.Payload, opts ...Option) *Generator {
+	g := &Generator{
+		registry: registry,
+		enums:    make(map[reflect.Type][]string),
+		nullable: make(map[string]bool),
+	}
+	// Message types are an enum of the registry's keys.
+	var types []string
+	for _, m := range g.messages() {
+		types = append(types, string(m.Type))
+	}
+	g.enums[reflect.TypeFor[protocol.MessageType]()] = types
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithEnum renders T as a union of values instead of any string.
+func WithEnum[T ~string](values ...T) Option {
+	return func(g *Generator) {
+		names := make([]string, len(values))
+		for i, value := range values {
+			names[i] = string(value)
+		}
+		g.enums[reflect.TypeFor[T]()] = names
+	}
+}
+
+// WithNullable marks pointer fields, given as "Struct.Field" by their Go
+// names, that may be null. Other pointers are assumed to always be set.
+func WithNullable(fields ...string) Option {
+	return func(g *Generator) {
+		for _, f := range fields {
+			g.nullable[f] = true
+		}
+	}
+}
+
+// messages returns the registered payloads sorted by message type.
+func (g *Generator) messages() []message {
+	messages := make([]message, 0, len(g.registry))
+	for msgType, constructor := range g.registry {
+		messages = append(messages, message{Type: msgType, Payload: reflect.TypeOf(constructor()).Elem()})
+	}
+	slices.SortFunc(messages, func(a, b message) int {
+		return strings.Compare(string(a.Type), string(b.Type))
+	})
+	return messages
+}
+
+// fields returns the JSON properties of struct t in declaration order.
+// Embedded structs are flattened like encoding/json does.
+func (g *Generator) fields(t reflect.Type) []field {
+	var fields []field
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, g.fields(f.Type)...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, field{
+			Name:     name,
+			Type:     f.Type,
+			Optional: slices.Contains(strings.Split(options, ","), "omitempty"),
+			Nullable: f.Type.Kind() == reflect.Pointer && g.nullable[t.Name()+"."+f.Name],
+		})
+	}
+	return fields
+}
+
+// named returns the message type enum and the enums and structs other than
+// payloads that the payloads refer to, sorted by name.
+func (g *Generator) named() []reflect.Type {
+	payloads := make(map[reflect.Type]bool)
+	for _, m := range g.messages() {
+		payloads[m.Payload] = true
+	}
+	seen := make(map[reflect.Type]bool)
+	var named []reflect.Type
+	var visit func(t reflect.Type)
+	visit = func(t reflect.Type) {
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if seen[t] || t == timeType {
+			return
+		}
+		seen[t] = true
+		if _, ok := g.enums[t]; ok {
+			named = append(named, t)
+			return
+		}
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		if t.Name() != "" && !payloads[t] {
+			named = append(named, t)
+		}
+		for _, f := range g.fields(t) {
+			visit(f.Type)
+		}
+	}
+	visit(reflect.TypeFor[protocol.MessageType]())
+	for _, m := range g.messages() {
+		visit(m.Payload)
+	}
+	slices.SortFunc(named, func(a, b reflect.Type) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return named
+}
@@ -0,0 +1,100 @@
+package payloadgen
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomlaws/wordle/internal/protocol"
+)
+
+type color string
+
+type owner struct {
+	ID string `json:"id"`
+}
+
+type paintPayload struct {
+	Color    color          `json:"color"`
+	Owner    *owner         `json:"owner"`
+	Previous *owner         `json:"previous"`
+	Layers   []int          `json:"layer_count,omitempty"`
+	Counts   map[string]int `json:"counts,omitempty"`
+	DryAt    time.Time      `json:"dry_at"`
+	internal string
+	Skipped  string `json:"-"`
+}
+
+func (p *paintPayload) MessageType() protocol.MessageType {
+	return "paint"
+}
+
+func newTestGenerator() *Generator {
+	registry := map[protocol.MessageType]func() protocol.Payload{
+		"paint": func() protocol.Payload { return &paintPayload{} },
+	}
+	return New(registry,
+		WithEnum[color]("red", "blue"),
+		WithNullable("paintPayload.Previous"),
+	)
+}
+
+func TestTypeScript(t *testing.T) {
+	ts := string(newTestGenerator().TypeScript())
+	for _, want := range []string{
+		"export type color = 'red' | 'blue';",
+		"export type MessageType = 'paint';",
+		"export interface owner {\n    id: string;\n}",
+		"export class paintPayload {\n    color!: color;\n    owner!: owner;\n    previous!: owner | null;\n    layerCount?: number[];\n    counts?: Record<string, number>;\n    dryAt!: string;\n\n    MessageType(): string {\n        return 'paint';\n    }\n}",
+		"    | { type: 'paint'; payload: paintPayload };",
+		"    ['paint', () => new paintPayload()],",
+	} {
+		if !strings.Contains(ts, want) {
+			t.Errorf("Expected the TypeScript to contain\n%s\ngot\n%s", want, ts)
+		}
+	}
+	if strings.Contains(ts, "internal") || strings.Contains(ts, "Skipped") {
+		t.Errorf("Expected unexported and skipped fields to be left out, got\n%s", ts)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := newTestGenerator().JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+	var schema struct {
+		OneOf []struct {
+			Properties struct {
+				Type struct {
+					Const string `json:"const"`
+				} `json:"type"`
+			} `json:"properties"`
+		} `json:"oneOf"`
+		Defs map[string]struct {
+			Enum       []string                   `json:"enum"`
+			Properties map[string]json.RawMessage `json:"properties"`
+			Required   []string                   `json:"required"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if len(schema.OneOf) != 1 || schema.OneOf[0].Properties.Type.Const != "paint" {
+		t.Errorf("Expected one paint message, got %+v", schema.OneOf)
+	}
+	if got := schema.Defs["color"].Enum; len(got) != 2 {
+		t.Errorf("Expected the color enum, got %v", got)
+	}
+	payload := schema.Defs["paintPayload"]
+	if got := strings.Join(payload.Required, ","); got != "color,owner,previous,dry_at" {
+		t.Errorf("Expected the fields without omitempty to be required, got %s", got)
+	}
+	if got := string(payload.Properties["dry_at"]); !strings.Contains(got, "date-time") {
+		t.Errorf("Expected times to be date-time strings, got %s", got)
+	}
+	if got := string(payload.Properties["previous"]); !strings.Contains(got, "null") {
+		t.Errorf("Expected a nullable pointer, got %s", got)
+	}
+}
@@ -0,0 +1,102 @@
+package payloadgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// JSONSchema renders the messages as a JSON Schema (draft 2020-12) document:
+// one of the registered message types, each with its payload. Property names
+// are the JSON names used on the wire.
+func (g *Generator) JSONSchema() ([]byte, error) {
+	defs := make(map[string]any)
+	for _, t := range g.named() {
+		if values, ok := g.enums[t]; ok {
+			defs[t.Name()] = map[string]any{"type": "string", "enum": values}
+			continue
+		}
+		defs[t.Name()] = g.objectSchema(t)
+	}
+	var oneOf []any
+	for _, m := range g.messages() {
+		payload := g.objectSchema(m.Payload)
+		payload["description"] = fmt.Sprintf("The payload of %s messages.", m.Type)
+		defs[m.Payload.Name()] = payload
+		oneOf = append(oneOf, map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"type":    map[string]any{"const": m.Type},
+				"payload": ref(m.Payload.Name()),
+			},
+			"required": []string{"type", "payload"},
+		})
+	}
+	schema := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "Protocol messages",
+		"oneOf":   oneOf,
+		"$defs":   defs,
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (g *Generator) objectSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+	for _, f := range g.fields(t) {
+		schema := g.schema(f.Type)
+		if f.Nullable {
+			schema = map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+		}
+		properties[f.Name] = schema
+		if !f.Optional {
+			required = append(required, f.Name)
+		}
+	}
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+func (g *Generator) schema(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	if _, ok := g.enums[t]; ok {
+		return ref(t.Name())
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() != "" {
+			return ref(t.Name())
+		}
+		return g.objectSchema(t)
+	}
+	return map[string]any{}
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/$defs/" + name}
+}
@@ -0,0 +1,33 @@
+package payloadgen
+
+import (
+	"reflect"
+
+	"github.com/tomlaws/wordle/internal/protocol"
+)
+
+// Generator renders the payloads of a registry as TypeScript and JSON Schema.
+// Named types are emitted under their Go name, so names must be unique across
+// packages.
+type Generator struct {
+	registry map[protocol.MessageType]func() protocol.Payload
+	enums    map[reflect.Type][]string
+	nullable map[string]bool
+}
+
+// Option configures a Generator created by New.
+type Option func(*Generator)
+
+// message is a registered payload struct and its message type.
+type message struct {
+	Type    protocol.MessageType
+	Payload reflect.Type
+}
+
+// field is a JSON property of a struct.
+type field struct {
+	Name     string
+	Type     reflect.Type
+	Optional bool
+	Nullable bool
+}
@@ -0,0 +1,130 @@
+package payloadgen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TypeScript renders an enum as a union of string literals, every other named
+// struct as an interface and every payload as a class that the web client's
+// Protocol can instantiate by message type. Property names are camelCase,
+// like the keys the web client converts incoming messages to.
+func (g *Generator) TypeScript() []byte {
+	var b strings.Builder
+	b.WriteString("// Code generated by payloadgen. DO NOT EDIT.\n\n")
+	b.WriteString("import type { Payload } from '$lib/utils/message';\n")
+	for _, t := range g.named() {
+		b.WriteString("\n")
+		if values, ok := g.enums[t]; ok {
+			fmt.Fprintf(&b, "export type %s = %s;\n", t.Name(), literals(values))
+			continue
+		}
+		fmt.Fprintf(&b, "export interface %s {\n", t.Name())
+		for _, f := range g.fields(t) {
+			optional := ""
+			if f.Optional {
+				optional = "?"
+			}
+			fmt.Fprintf(&b, "    %s%s: %s;\n", camelCase(f.Name), optional, g.tsField(f))
+		}
+		b.WriteString("}\n")
+	}
+	messages := g.messages()
+	for _, m := range messages {
+		fmt.Fprintf(&b, "\nexport class %s {\n", m.Payload.Name())
+		fields := g.fields(m.Payload)
+		for _, f := range fields {
+			mark := "!"
+			if f.Optional {
+				mark = "?"
+			}
+			fmt.Fprintf(&b, "    %s%s: %s;\n", camelCase(f.Name), mark, g.tsField(f))
+		}
+		if len(fields) > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "    MessageType(): string {\n        return '%s';\n    }\n}\n", m.Type)
+	}
+	b.WriteString("\nexport type Message =\n")
+	for i, m := range messages {
+		fmt.Fprintf(&b, "    | { type: '%s'; payload: %s }", m.Type, m.Payload.Name())
+		if i == len(messages)-1 {
+			b.WriteString(";")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\nexport const payloadRegistry = new Map<string, () => Payload>([\n")
+	for _, m := range messages {
+		fmt.Fprintf(&b, "    ['%s', () => new %s()],\n", m.Type, m.Payload.Name())
+	}
+	b.WriteString("]);\n")
+	return []byte(b.String())
+}
+
+func (g *Generator) tsField(f field) string {
+	t := g.tsType(f.Type)
+	if f.Nullable {
+		t += " | null"
+	}
+	return t
+}
+
+func (g *Generator) tsType(t reflect.Type) string {
+	if t == timeType {
+		return "string"
+	}
+	if _, ok := g.enums[t]; ok {
+		return t.Name()
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.tsType(t.Elem())
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return g.tsType(t.Elem()) + "[]"
+	case reflect.Map:
+		return "Record<string, " + g.tsType(t.Elem()) + ">"
+	case reflect.Struct:
+		if t.Name() != "" {
+			return t.Name()
+		}
+		var parts []string
+		for _, f := range g.fields(t) {
+			optional := ""
+			if f.Optional {
+				optional = "?"
+			}
+			parts = append(parts, fmt.Sprintf("%s%s: %s;", camelCase(f.Name), optional, g.tsField(f)))
+		}
+		return "{ " + strings.Join(parts, " ") + " }"
+	}
+	return "unknown"
+}
+
+// literals joins values as a union of string literals.
+func literals(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + value + "'"
+	}
+	return strings.Join(quoted, " | ")
+}
+
+// camelCase converts a snake_case JSON name to camelCase.
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
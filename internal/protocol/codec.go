@@ -0,0 +1,138 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Built-in codecs. JSON is the default and the only one browsers are offered;
+// CBOR and MessagePack are compact binary encodings for native clients.
+var (
+	JSON        Codec = jsonCodec{}
+	CBOR        Codec = newCBORCodec()
+	MessagePack Codec = msgpackCodec{}
+)
+
+// Codecs lists the built-in codecs.
+var Codecs = []Codec{JSON, CBOR, MessagePack}
+
+// LookupCodec returns the built-in codec for an encoding name.
+func LookupCodec(name string) (Codec, error) {
+	for _, codec := range Codecs {
+		if codec.Name() == name {
+			return codec, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown encoding: %s", name)
+}
+
+// CodecNames returns the encoding names of codecs.
+func CodecNames(codecs []Codec) []string {
+	names := make([]string, len(codecs))
+	for i, codec := range codecs {
+		names[i] = codec.Name()
+	}
+	return names
+}
+
+func (jsonCodec) Name() string {
+	return EncodingJSON
+}
+
+func (jsonCodec) Binary() bool {
+	return false
+}
+
+func (jsonCodec) Encode(payload Payload) ([]byte, error) {
+	return json.Marshal(envelope{Type: payload.MessageType(), Payload: payload})
+}
+
+func (jsonCodec) Decode(data []byte) (MessageType, []byte, error) {
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return "", nil, err
+	}
+	return msg.Type, msg.Payload, nil
+}
+
+func (jsonCodec) Unmarshal(data []byte, payload Payload) error {
+	return json.Unmarshal(data, payload)
+}
+
+func newCBORCodec() cborCodec {
+	encMode, err := cbor.EncOptions{Time: cbor.TimeRFC3339Nano}.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return cborCodec{encMode: encMode}
+}
+
+func (cborCodec) Name() string {
+	return EncodingCBOR
+}
+
+func (cborCodec) Binary() bool {
+	return true
+}
+
+func (c cborCodec) Encode(payload Payload) ([]byte, error) {
+	return c.encMode.Marshal(envelope{Type: payload.MessageType(), Payload: payload})
+}
+
+func (cborCodec) Decode(data []byte) (MessageType, []byte, error) {
+	var msg struct {
+		Type    MessageType     `json:"type"`
+		Payload cbor.RawMessage `json:"payload"`
+	}
+	if err := cbor.Unmarshal(data, &msg); err != nil {
+		return "", nil, err
+	}
+	return msg.Type, msg.Payload, nil
+}
+
+func (cborCodec) Unmarshal(data []byte, payload Payload) error {
+	return cbor.Unmarshal(data, payload)
+}
+
+func (msgpackCodec) Name() string {
+	return EncodingMessagePack
+}
+
+func (msgpackCodec) Binary() bool {
+	return true
+}
+
+func (msgpackCodec) Encode(payload Payload) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(envelope{Type: payload.MessageType(), Payload: payload}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c msgpackCodec) Decode(data []byte) (MessageType, []byte, error) {
+	var msg struct {
+		Type    MessageType        `json:"type"`
+		Payload msgpack.RawMessage `json:"payload"`
+	}
+	if err := c.unmarshal(data, &msg); err != nil {
+		return "", nil, err
+	}
+	return msg.Type, msg.Payload, nil
+}
+
+func (c msgpackCodec) Unmarshal(data []byte, payload Payload) error {
+	return c.unmarshal(data, payload)
+}
+
+func (msgpackCodec) unmarshal(data []byte, v any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
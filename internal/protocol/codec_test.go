@@ -0,0 +1,76 @@
+package protocol
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testPayload struct {
+	Name     string    `json:"name"`
+	Count    int       `json:"count,omitempty"`
+	Words    []string  `json:"words"`
+	Deadline time.Time `json:"deadline"`
+}
+
+func (p *testPayload) MessageType() MessageType {
+	return "test"
+}
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	sent := &testPayload{
+		Name:     "ñandú",
+		Words:    []string{"apple", "beach"},
+		Deadline: time.Date(2025, 1, 2, 3, 4, 5, 6000, time.UTC),
+	}
+	for _, codec := range Codecs {
+		data, err := codec.Encode(sent)
+		if err != nil {
+			t.Fatalf("%s: encode failed: %v", codec.Name(), err)
+		}
+		msgType, raw, err := codec.Decode(data)
+		if err != nil {
+			t.Fatalf("%s: decode failed: %v", codec.Name(), err)
+		}
+		if msgType != "test" {
+			t.Errorf("%s: expected type test, got %s", codec.Name(), msgType)
+		}
+		var received testPayload
+		if err := codec.Unmarshal(raw, &received); err != nil {
+			t.Fatalf("%s: unmarshal failed: %v", codec.Name(), err)
+		}
+		// MessagePack decodes times in the local time zone.
+		if received.Deadline.Equal(sent.Deadline) {
+			received.Deadline = sent.Deadline
+		}
+		if !reflect.DeepEqual(&received, sent) {
+			t.Errorf("%s: expected %+v, got %+v", codec.Name(), sent, received)
+		}
+	}
+}
+
+func TestCodecs_BinaryIsSmaller(t *testing.T) {
+	payload := &testPayload{Name: "apple", Count: 3, Words: []string{"apple", "beach", "crane"}}
+	text, _ := JSON.Encode(payload)
+	for _, codec := range []Codec{CBOR, MessagePack} {
+		data, _ := codec.Encode(payload)
+		if !codec.Binary() || len(data) >= len(text) {
+			t.Errorf("%s: expected a binary encoding smaller than %d bytes of JSON, got %d", codec.Name(), len(text), len(data))
+		}
+		if bytes.Contains(data, []byte(`"name"`)) {
+			t.Errorf("%s: expected no JSON in a binary message", codec.Name())
+		}
+	}
+}
+
+func TestLookupCodec(t *testing.T) {
+	for _, codec := range Codecs {
+		if got, err := LookupCodec(codec.Name()); err != nil || got != codec {
+			t.Errorf("Expected %s to be found, got %v", codec.Name(), err)
+		}
+	}
+	if _, err := LookupCodec("xml"); err == nil {
+		t.Error("Expected an unknown encoding to fail")
+	}
+}
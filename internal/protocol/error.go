@@ -0,0 +1,20 @@
+package protocol
+
+import "fmt"
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %v", e.Code, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Payload returns the error message to send back to the sender.
+func (e *Error) Payload() *ErrorPayload {
+	return &ErrorPayload{
+		Code:     e.Code,
+		Rejected: e.Type,
+		Message:  e.Err.Error(),
+	}
+}
@@ -0,0 +1,68 @@
+package protocol
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Version is the newest protocol version this build speaks, and MinVersion
+// the oldest one it still accepts.
+const (
+	Version    = 1
+	MinVersion = 1
+)
+
+// Negotiate picks the version, encoding and compression for a client's hello
+// from what the server supports. It fails if the client's version is too old,
+// if the client cannot play one of the server's variants, word length or
+// number of boards, or if they have no encoding or compression in common.
+// The client's preference order wins.
+func Negotiate(hello *HelloPayload, server Features) (*WelcomePayload, error) {
+	if hello.Version < MinVersion {
+		return nil, fmt.Errorf("protocol version %d is not supported, the server needs %d or newer", hello.Version, MinVersion)
+	}
+	for _, variant := range server.Variants {
+		if !slices.Contains(hello.Features.Variants, variant) {
+			return nil, fmt.Errorf("this server plays %s, which the client does not support", variant)
+		}
+	}
+	if lengths := hello.Features.WordLengths; len(lengths) > 0 {
+		for _, length := range server.WordLengths {
+			if !slices.Contains(lengths, length) {
+				return nil, fmt.Errorf("this server plays %d-letter words, which the client does not support", length)
+			}
+		}
+	}
+	if boards := hello.Features.Boards; boards > 0 && server.Boards > boards {
+		return nil, fmt.Errorf("this server plays %d boards at once, the client shows at most %d", server.Boards, boards)
+	}
+	encoding, err := pick("encoding", hello.Features.Encodings, server.Encodings, EncodingJSON)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := pick("compression", hello.Features.Compression, server.Compression, CompressionNone)
+	if err != nil {
+		return nil, err
+	}
+	return &WelcomePayload{
+		Version:     min(hello.Version, Version),
+		Variants:    server.Variants,
+		Encoding:    encoding,
+		Compression: compression,
+	}, nil
+}
+
+// pick returns the first offer the server supports. A client that offers
+// nothing gets the fallback.
+func pick(feature string, offers, supported []string, fallback string) (string, error) {
+	if len(offers) == 0 {
+		offers = []string{fallback}
+	}
+	for _, offer := range offers {
+		if slices.Contains(supported, offer) {
+			return offer, nil
+		}
+	}
+	return "", fmt.Errorf("no common %s, the server supports %s", feature, strings.Join(supported, ", "))
+}
@@ -0,0 +1,57 @@
+package protocol
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	server := Features{
+		Variants:    []string{"classic", "wordle"},
+		Encodings:   []string{EncodingJSON},
+		Compression: []string{CompressionDeflate, CompressionNone},
+		WordLengths: []int{5},
+		Boards:      1,
+	}
+	hello := &HelloPayload{
+		Version: Version + 1,
+		Features: Features{
+			Variants:    []string{"classic", "absurd", "wordle"},
+			Compression: []string{CompressionNone, CompressionDeflate},
+			WordLengths: []int{5},
+			Boards:      1,
+		},
+	}
+	welcome, err := Negotiate(hello, server)
+	if err != nil {
+		t.Fatalf("Expected the hello to be accepted, got %v", err)
+	}
+	if welcome.Version != Version {
+		t.Errorf("Expected version %d, got %d", Version, welcome.Version)
+	}
+	if welcome.Encoding != EncodingJSON {
+		t.Errorf("Expected json to be the default encoding, got %s", welcome.Encoding)
+	}
+	if welcome.Compression != CompressionNone {
+		t.Errorf("Expected the client's first choice of compression, got %s", welcome.Compression)
+	}
+}
+
+func TestNegotiate_Rejects(t *testing.T) {
+	server := Features{
+		Variants:    []string{"nerdle"},
+		Encodings:   []string{EncodingJSON},
+		Compression: []string{CompressionNone},
+		WordLengths: []int{8},
+		Boards:      2,
+	}
+	tests := map[string]*HelloPayload{
+		"word length":     {Version: Version, Features: Features{Variants: []string{"nerdle"}, WordLengths: []int{5}}},
+		"too many boards": {Version: Version, Features: Features{Variants: []string{"nerdle"}, Boards: 1}},
+		"old version":     {Version: MinVersion - 1, Features: Features{Variants: []string{"nerdle"}}},
+		"missing variant": {Version: Version, Features: Features{Variants: []string{"classic"}}},
+		"no encoding":     {Version: Version, Features: Features{Variants: []string{"nerdle"}, Encodings: []string{"xml"}}},
+	}
+	for name, hello := range tests {
+		if _, err := Negotiate(hello, server); err == nil {
+			t.Errorf("%s: expected the hello to be rejected", name)
+		}
+	}
+}
@@ -3,17 +3,48 @@ package protocol
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 func NewProtocol(
 	registry map[MessageType]func() Payload,
+	opts ...Option,
 ) *Protocol {
-	return &Protocol{
+	p := &Protocol{
 		registry: registry,
+		codec:    JSON,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// WithCodec encodes messages with codec instead of JSON.
+func WithCodec(codec Codec) Option {
+	return func(p *Protocol) {
+		p.codec = codec
+	}
+}
+
+// WithErrorHandler calls handler with every message the protocol drops.
+func WithErrorHandler(handler func(err *Error)) Option {
+	return func(p *Protocol) {
+		p.onError = handler
 	}
 }
 
-func (p *Protocol) wrapMessage(payload Payload) (*Message, error) {
+// WithRateLimit drops incoming messages beyond the first messages of every
+// interval, reporting them as rate limited.
+func WithRateLimit(messages int, interval time.Duration) Option {
+	return func(p *Protocol) {
+		p.rateLimit = messages
+		p.rateInterval = interval
+	}
+}
+
+// NewMessage wraps payload in a JSON Message, as used by the handshake.
+func NewMessage(payload Payload) (*Message, error) {
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("wrap failed: %w", err)
@@ -25,32 +56,52 @@ func (p *Protocol) wrapMessage(payload Payload) (*Message, error) {
 	return &msg, nil
 }
 
-func (p *Protocol) unwrapMessage(msg *Message) (Payload, error) {
-	constructor, ok := p.registry[msg.Type]
+func (p *Protocol) unwrapMessage(data []byte) (Payload, *Error) {
+	msgType, raw, err := p.codec.Decode(data)
+	if err != nil {
+		return nil, &Error{Code: CodeBadPayload, Err: fmt.Errorf("malformed message: %w", err)}
+	}
+	if !p.allow(time.Now()) {
+		return nil, &Error{Code: CodeRateLimited, Type: msgType, Err: fmt.Errorf("more than %d messages in %v", p.rateLimit, p.rateInterval)}
+	}
+	constructor, ok := p.registry[msgType]
 	if !ok {
-		return nil, fmt.Errorf("unknown message type: %s", msg.Type)
+		return nil, &Error{Code: CodeUnknownType, Type: msgType, Err: fmt.Errorf("unknown message type: %s", msgType)}
 	}
 
 	payload := constructor()
-	if err := json.Unmarshal(msg.Payload, payload); err != nil {
-		return nil, fmt.Errorf("unmarshal failed for type %s: %w", msg.Type, err)
+	if err := p.codec.Unmarshal(raw, payload); err != nil {
+		return nil, &Error{Code: CodeBadPayload, Type: msgType, Err: fmt.Errorf("unmarshal failed for type %s: %w", msgType, err)}
 	}
 	return payload, nil
 }
 
-func (p *Protocol) WrapChannel(ch chan json.RawMessage) chan Payload {
+func (p *Protocol) report(err *Error) {
+	if p.onError != nil {
+		p.onError(err)
+	}
+}
+
+// allow counts an incoming message against the rate limit.
+func (p *Protocol) allow(now time.Time) bool {
+	if p.rateLimit <= 0 {
+		return true
+	}
+	if now.Sub(p.windowStart) >= p.rateInterval {
+		p.windowStart = now
+		p.windowCount = 0
+	}
+	p.windowCount++
+	return p.windowCount <= p.rateLimit
+}
+
+func (p *Protocol) WrapChannel(ch chan []byte) chan Payload {
 	wrapped := make(chan Payload)
 	go func() {
-		defer close(wrapped)
 		for payload := range wrapped {
-			msg, err := p.wrapMessage(payload)
-			if err != nil {
-				// Handle error (e.g., log it)
-				continue
-			}
-			data, err := json.Marshal(msg)
+			data, err := p.codec.Encode(payload)
 			if err != nil {
-				// Handle error (e.g., log it)
+				p.report(&Error{Code: CodeBadPayload, Type: payload.MessageType(), Err: fmt.Errorf("wrap failed: %w", err), Outgoing: true})
 				continue
 			}
 			ch <- data
@@ -59,19 +110,14 @@ func (p *Protocol) WrapChannel(ch chan json.RawMessage) chan Payload {
 	return wrapped
 }
 
-func (p *Protocol) UnwrapChannel(ch chan json.RawMessage) chan Payload {
+func (p *Protocol) UnwrapChannel(ch chan []byte) chan Payload {
 	unwrapped := make(chan Payload)
 	go func() {
 		defer close(unwrapped)
-		for rawMsg := range ch {
-			var msg Message
-			if err := json.Unmarshal([]byte(rawMsg), &msg); err != nil {
-				// Handle error (e.g., log it)
-				continue
-			}
-			payload, err := p.unwrapMessage(&msg)
+		for data := range ch {
+			payload, err := p.unwrapMessage(data)
 			if err != nil {
-				// Handle error (e.g., log it)
+				p.report(err)
 				continue
 			}
 			unwrapped <- payload
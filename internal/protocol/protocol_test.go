@@ -0,0 +1,57 @@
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestUnwrapChannel_ReportsErrors(t *testing.T) {
+	registry := map[MessageType]func() Payload{
+		MsgTypeHello: func() Payload { return &HelloPayload{} },
+	}
+	errs := make(chan *Error, 1)
+	p := NewProtocol(registry, WithErrorHandler(func(err *Error) { errs <- err }))
+	raw := make(chan []byte)
+	unwrapped := p.UnwrapChannel(raw)
+	tests := []struct {
+		message string
+		code    ErrorCode
+	}{
+		{`not json`, CodeBadPayload},
+		{`{"type":"dance","payload":{}}`, CodeUnknownType},
+		{`{"type":"hello","payload":{"version":"one"}}`, CodeBadPayload},
+	}
+	for _, test := range tests {
+		raw <- json.RawMessage(test.message)
+		if err := <-errs; err.Code != test.code {
+			t.Errorf("%s: expected %s, got %v", test.message, test.code, err)
+		}
+	}
+	raw <- []byte(`{"type":"hello","payload":{"version":1}}`)
+	if payload := (<-unwrapped).(*HelloPayload); payload.Version != 1 {
+		t.Errorf("Expected version 1, got %d", payload.Version)
+	}
+}
+
+func TestUnwrapChannel_RateLimit(t *testing.T) {
+	registry := map[MessageType]func() Payload{
+		MsgTypeHello: func() Payload { return &HelloPayload{} },
+	}
+	errs := make(chan *Error, 1)
+	p := NewProtocol(registry,
+		WithErrorHandler(func(err *Error) { errs <- err }),
+		WithRateLimit(2, time.Hour),
+	)
+	raw := make(chan []byte)
+	unwrapped := p.UnwrapChannel(raw)
+	hello := []byte(`{"type":"hello","payload":{"version":1}}`)
+	for range 2 {
+		raw <- hello
+		<-unwrapped
+	}
+	raw <- hello
+	if err := <-errs; err.Code != CodeRateLimited || err.Type != MsgTypeHello {
+		t.Errorf("Expected the third hello to be rate limited, got %v", err)
+	}
+}
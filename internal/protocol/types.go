@@ -1,11 +1,25 @@
 package protocol
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
 
 type Protocol struct {
-	registry map[MessageType]func() Payload
+	registry     map[MessageType]func() Payload
+	codec        Codec
+	onError      func(err *Error)
+	rateLimit    int
+	rateInterval time.Duration
+	windowStart  time.Time
+	windowCount  int
 }
 
+// Option configures a Protocol created by NewProtocol.
+type Option func(*Protocol)
+
 type MessageType string
 
 type Message struct {
@@ -16,3 +30,113 @@ type Message struct {
 type Payload interface {
 	MessageType() MessageType
 }
+
+// Codec encodes messages for the wire. Decode only splits off the type, so
+// the payload can be decoded into the type's registered payload.
+type Codec interface {
+	// Name is the encoding's name in the handshake.
+	Name() string
+	// Binary reports whether messages are sent as binary WebSocket frames.
+	Binary() bool
+	Encode(payload Payload) ([]byte, error)
+	Decode(data []byte) (MessageType, []byte, error)
+	Unmarshal(data []byte, payload Payload) error
+}
+
+// envelope is an outgoing Message whose payload is encoded in the same pass.
+type envelope struct {
+	Type    MessageType `json:"type"`
+	Payload Payload     `json:"payload"`
+}
+
+type jsonCodec struct{}
+
+type cborCodec struct {
+	encMode cbor.EncMode
+}
+
+type msgpackCodec struct{}
+
+const (
+	MsgTypeHello   MessageType = "hello"
+	MsgTypeWelcome MessageType = "welcome"
+	MsgTypeError   MessageType = "error"
+)
+
+// Encodings and compressions a peer may offer in its hello.
+const (
+	EncodingJSON        = "json"
+	EncodingCBOR        = "cbor"
+	EncodingMessagePack = "msgpack"
+	CompressionNone     = "none"
+	CompressionDeflate  = "deflate"
+)
+
+// Features lists what a peer supports. A client lists every variant it can
+// play; a server lists the variants its games require.
+type Features struct {
+	Variants    []string `json:"variants"`
+	Encodings   []string `json:"encodings"`
+	Compression []string `json:"compression"`
+	// WordLengths lists the word lengths a client can show, or the length a
+	// server's games use. A client that lists none takes any length.
+	WordLengths []int `json:"word_lengths,omitempty"`
+	// Boards is the most boards a client can show at once, or the number a
+	// server's games use. A client that sends 0 takes any number.
+	Boards int `json:"boards,omitempty"`
+}
+
+// HelloPayload is the first message a client sends.
+type HelloPayload struct {
+	Version  int      `json:"version"`
+	Features Features `json:"features"`
+}
+
+func (p *HelloPayload) MessageType() MessageType {
+	return MsgTypeHello
+}
+
+// WelcomePayload is the server's answer to a hello it accepts.
+type WelcomePayload struct {
+	Version     int      `json:"version"`
+	Variants    []string `json:"variants"`
+	Encoding    string   `json:"encoding"`
+	Compression string   `json:"compression"`
+}
+
+func (p *WelcomePayload) MessageType() MessageType {
+	return MsgTypeWelcome
+}
+
+// ErrorCode says why a message was rejected.
+type ErrorCode string
+
+const (
+	CodeUnknownType ErrorCode = "unknown_type"
+	CodeBadPayload  ErrorCode = "bad_payload"
+	CodeOutOfTurn   ErrorCode = "out_of_turn"
+	CodeRateLimited ErrorCode = "rate_limited"
+)
+
+// ErrorCodes lists every error code.
+var ErrorCodes = []ErrorCode{CodeUnknownType, CodeBadPayload, CodeOutOfTurn, CodeRateLimited}
+
+// Error describes a message that was dropped. Outgoing errors are the
+// sender's own encoding failures; the rest are the peer's fault.
+type Error struct {
+	Code     ErrorCode
+	Type     MessageType
+	Err      error
+	Outgoing bool
+}
+
+// ErrorPayload tells the sender that one of its messages was rejected.
+type ErrorPayload struct {
+	Code     ErrorCode   `json:"code"`
+	Rejected MessageType `json:"rejected,omitempty"`
+	Message  string      `json:"message"`
+}
+
+func (p *ErrorPayload) MessageType() MessageType {
+	return MsgTypeError
+}
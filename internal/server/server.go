@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"strings"
@@ -9,14 +10,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"github.com/tomlaws/wordle/internal/protocol"
 	"github.com/tomlaws/wordle/pkg/utils"
 )
 
-var Upgrader = websocket.Upgrader{}
+var Upgrader = websocket.Upgrader{EnableCompression: true}
 
 const pingInterval = 15 * time.Second
 const pongWait = 25 * time.Second
 const writeWait = 5 * time.Second
+const helloWait = 10 * time.Second
 
 func handleRead(client *Client) {
 	defer func() {
@@ -30,14 +33,14 @@ func handleRead(client *Client) {
 		return nil
 	})
 	for {
-		var msg json.RawMessage
-		if err := client.conn.ReadJSON(&msg); err != nil {
+		_, msg, err := client.conn.ReadMessage()
+		if err != nil {
 			client.error <- err
 			log.Printf("Error reading message from player %s: %v", client.nickname, err)
 			break
 		}
 		client.incoming <- msg
-		log.Printf("Received message from player %s: %+v", client.nickname, utils.JsonToString(msg))
+		log.Printf("Received message from player %s: %+v", client.nickname, describe(client.codec, msg))
 	}
 }
 
@@ -51,12 +54,12 @@ func handleWrite(client *Client) {
 		select {
 		case msg := <-client.outgoing:
 			client.conn.SetWriteDeadline(time.Now().Add(writeWait))
-			if err := client.conn.WriteJSON(msg); err != nil {
+			if err := client.conn.WriteMessage(frameType(client.codec), msg); err != nil {
 				client.error <- err
 				log.Printf("Error sending message to player %s: %v", client.nickname, err)
 				break
 			}
-			log.Printf("Sending message to player %s: %s", client.nickname, utils.JsonToString(msg))
+			log.Printf("Sending message to player %s: %s", client.nickname, describe(client.codec, msg))
 		case <-ticker.C:
 			client.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if err := client.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
@@ -67,7 +70,68 @@ func handleWrite(client *Client) {
 	}
 }
 
-func socketHandler(newClientCallback func(client *Client)) func(w http.ResponseWriter, r *http.Request) {
+// frameType returns the WebSocket frame type for messages in codec.
+func frameType(codec protocol.Codec) int {
+	if codec.Binary() {
+		return websocket.BinaryMessage
+	}
+	return websocket.TextMessage
+}
+
+// describe formats a message for the log.
+func describe(codec protocol.Codec, msg []byte) string {
+	if codec.Binary() {
+		return fmt.Sprintf("%d bytes of %s", len(msg), codec.Name())
+	}
+	return utils.JsonToString(json.RawMessage(msg))
+}
+
+// handshake waits for the client's hello and answers with a welcome. If the
+// client is incompatible, the connection is closed with the reason.
+func handshake(conn *websocket.Conn, features protocol.Features) (*protocol.WelcomePayload, error) {
+	welcome, err := negotiate(conn, features)
+	if err != nil {
+		closeWithReason(conn, err.Error())
+		return nil, err
+	}
+	msg, err := protocol.NewMessage(welcome)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	if err := conn.WriteJSON(msg); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.EnableWriteCompression(welcome.Compression == protocol.CompressionDeflate)
+	return welcome, nil
+}
+
+// closeWithReason closes conn with a policy violation, telling the client why.
+func closeWithReason(conn *websocket.Conn, reason string) {
+	message := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason)
+	conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeWait))
+	conn.Close()
+}
+
+func negotiate(conn *websocket.Conn, features protocol.Features) (*protocol.WelcomePayload, error) {
+	conn.SetReadDeadline(time.Now().Add(helloWait))
+	var msg protocol.Message
+	if err := conn.ReadJSON(&msg); err != nil {
+		return nil, fmt.Errorf("expected hello: %w", err)
+	}
+	if msg.Type != protocol.MsgTypeHello {
+		return nil, fmt.Errorf("expected hello, got %s", msg.Type)
+	}
+	var hello protocol.HelloPayload
+	if err := json.Unmarshal(msg.Payload, &hello); err != nil {
+		return nil, fmt.Errorf("malformed hello: %w", err)
+	}
+	return protocol.Negotiate(&hello, features)
+}
+
+func socketHandler(config config, newClientCallback func(client *Client)) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		nickname := strings.TrimSpace(r.URL.Query().Get("nickname"))
 		if len(nickname) < 3 || len(nickname) > 16 {
@@ -82,13 +146,25 @@ func socketHandler(newClientCallback func(client *Client)) func(w http.ResponseW
 			log.Print("Error during connection upgradation:", err)
 			return
 		}
+		welcome, err := handshake(conn, config.features)
+		if err != nil {
+			log.Printf("Handshake with player %s failed: %v", nickname, err)
+			return
+		}
+		codec, err := protocol.LookupCodec(welcome.Encoding)
+		if err != nil {
+			closeWithReason(conn, err.Error())
+			return
+		}
 		client := &Client{
 			id:       uuid.New().String(),
 			nickname: nickname,
 			conn:     conn,
-			incoming: make(chan json.RawMessage),
-			outgoing: make(chan json.RawMessage),
+			incoming: make(chan []byte),
+			outgoing: make(chan []byte),
 			error:    make(chan error),
+			welcome:  welcome,
+			codec:    codec,
 		}
 		go handleRead(client)
 		go handleWrite(client)
@@ -96,9 +172,40 @@ func socketHandler(newClientCallback func(client *Client)) func(w http.ResponseW
 	}
 }
 
+// WithVariants rejects clients that cannot play every one of variants.
+func WithVariants(variants ...string) Option {
+	return func(c *config) {
+		c.features.Variants = variants
+	}
+}
+
+// WithWordLength rejects clients that cannot show words of length.
+func WithWordLength(length int) Option {
+	return func(c *config) {
+		c.features.WordLengths = []int{length}
+	}
+}
+
+// WithBoards rejects clients that cannot show that many boards at once.
+func WithBoards(boards int) Option {
+	return func(c *config) {
+		c.features.Boards = boards
+	}
+}
+
 func NewServer(
 	newClientCallback func(client *Client),
+	opts ...Option,
 ) func(w http.ResponseWriter, r *http.Request) {
+	config := config{
+		features: protocol.Features{
+			Encodings:   protocol.CodecNames(protocol.Codecs),
+			Compression: []string{protocol.CompressionDeflate, protocol.CompressionNone},
+		},
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
 	// set deadline for both read and write to 60 seconds
-	return socketHandler(newClientCallback)
+	return socketHandler(config, newClientCallback)
 }
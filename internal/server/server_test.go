@@ -0,0 +1,79 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tomlaws/wordle/internal/client"
+	"github.com/tomlaws/wordle/internal/protocol"
+)
+
+func newTestServer(t *testing.T, clients chan *Client, opts ...Option) string {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/socket", NewServer(func(client *Client) {
+		clients <- client
+	}, opts...))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestHandshake(t *testing.T) {
+	clients := make(chan *Client, 1)
+	address := newTestServer(t, clients, WithVariants("classic", "wordle"))
+	c, err := client.NewClient(address, "alice", protocol.Features{
+		Variants:    []string{"classic", "wordle", "nerdle"},
+		Compression: []string{protocol.CompressionDeflate},
+	})
+	if err != nil {
+		t.Fatalf("Expected the handshake to succeed, got %v", err)
+	}
+	defer c.Stop()
+	if got := c.Welcome(); got.Version != protocol.Version || got.Compression != protocol.CompressionDeflate {
+		t.Errorf("Unexpected welcome: %+v", got)
+	}
+	if got := (<-clients).Welcome(); got.Encoding != protocol.EncodingJSON {
+		t.Errorf("Expected the server to keep the welcome, got %+v", got)
+	}
+}
+
+func TestHandshake_RejectsWithReason(t *testing.T) {
+	clients := make(chan *Client, 1)
+	address := newTestServer(t, clients, WithVariants("nerdle"))
+	_, err := client.NewClient(address, "alice", protocol.Features{Variants: []string{"classic"}})
+	if err == nil || !strings.Contains(err.Error(), "nerdle") {
+		t.Errorf("Expected the server to name the missing variant, got %v", err)
+	}
+	select {
+	case <-clients:
+		t.Error("Expected a rejected client to never reach the lobby")
+	default:
+	}
+}
+
+func TestHandshake_BinaryEncoding(t *testing.T) {
+	clients := make(chan *Client, 1)
+	address := newTestServer(t, clients)
+	c, err := client.NewClient(address, "alice", protocol.Features{
+		Encodings: []string{protocol.EncodingMessagePack, protocol.EncodingJSON},
+	})
+	if err != nil {
+		t.Fatalf("Expected the handshake to succeed, got %v", err)
+	}
+	defer c.Stop()
+	server := <-clients
+	if c.Codec() != protocol.MessagePack || server.Codec() != protocol.MessagePack {
+		t.Fatalf("Expected both sides to use msgpack, got %s and %s", c.Codec().Name(), server.Codec().Name())
+	}
+	sent := &protocol.ErrorPayload{Code: protocol.CodeRateLimited, Message: "slow down"}
+	data, _ := server.Codec().Encode(sent)
+	server.Outgoing() <- data
+	received := protocol.NewProtocol(map[protocol.MessageType]func() protocol.Payload{
+		protocol.MsgTypeError: func() protocol.Payload { return &protocol.ErrorPayload{} },
+	}, protocol.WithCodec(c.Codec())).UnwrapChannel(c.Incoming())
+	if got := (<-received).(*protocol.ErrorPayload); *got != *sent {
+		t.Errorf("Expected %+v over a binary frame, got %+v", sent, got)
+	}
+}
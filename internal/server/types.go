@@ -1,18 +1,26 @@
 package server
 
 import (
-	"encoding/json"
-
 	"github.com/gorilla/websocket"
+	"github.com/tomlaws/wordle/internal/protocol"
 )
 
+// Option configures the handler created by NewServer.
+type Option func(*config)
+
+type config struct {
+	features protocol.Features
+}
+
 type Client struct {
 	conn     *websocket.Conn
 	id       string
 	nickname string
-	incoming chan json.RawMessage
-	outgoing chan json.RawMessage
+	incoming chan []byte
+	outgoing chan []byte
 	error    chan error
+	welcome  *protocol.WelcomePayload
+	codec    protocol.Codec
 }
 
 func (c *Client) ID() string {
@@ -23,14 +31,29 @@ func (c *Client) Nickname() string {
 	return c.nickname
 }
 
-func (c *Client) Incoming() chan json.RawMessage {
+func (c *Client) Incoming() chan []byte {
 	return c.incoming
 }
 
-func (c *Client) Outgoing() chan json.RawMessage {
+func (c *Client) Outgoing() chan []byte {
 	return c.outgoing
 }
 
 func (c *Client) Error() chan error {
 	return c.error
 }
+
+// Welcome returns what the client and server agreed on in the handshake.
+func (c *Client) Welcome() *protocol.WelcomePayload {
+	return c.welcome
+}
+
+// Codec returns the encoding agreed on in the handshake.
+func (c *Client) Codec() protocol.Codec {
+	return c.codec
+}
+
+// Disconnect closes the connection, telling the client why.
+func (c *Client) Disconnect(reason string) {
+	closeWithReason(c.conn, reason)
+}
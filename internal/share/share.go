@@ -0,0 +1,269 @@
+package share
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tomlaws/wordle/internal/game"
+)
+
+// DefaultTitle names the game in the header when Grid.Title is empty.
+const DefaultTitle = "Wordle"
+
+// Tiles written for each match type.
+const (
+	MissTile    = "⬛"
+	PresentTile = "🟨"
+	HitTile     = "🟩"
+	HigherTile  = "🔼"
+	LowerTile   = "🔽"
+)
+
+// tiles maps every tile accepted by Parse to its match type, including the
+// light theme and high contrast variants.
+var tiles = map[rune]game.MatchType{
+	'⬛': game.Miss,
+	'⬜': game.Miss,
+	'🟨': game.Present,
+	'🟦': game.Present,
+	'🟩': game.Hit,
+	'🟧': game.Hit,
+	'🔼': game.Higher,
+	'🔽': game.Lower,
+}
+
+// header matches "Wordle #1,234 4&X/6*". The puzzle number is optional and
+// may omit the #, as in the official share text.
+var header = regexp.MustCompile(`^(\S.*?) (?:#?([0-9][0-9,]*) )?((?:[0-9]+|X)(?:&(?:[0-9]+|X))*)/([0-9]+)(\*?)$`)
+
+// New builds the grid of a game from the attempts of each board.
+func New(maxGuesses int, hardMode bool, boards ...[][]game.LetterResult) *Grid {
+	grid := &Grid{
+		MaxGuesses: maxGuesses,
+		HardMode:   hardMode,
+		Boards:     make([][][]game.MatchType, len(boards)),
+	}
+	for i, attempts := range boards {
+		rows := make([][]game.MatchType, len(attempts))
+		for j, attempt := range attempts {
+			rows[j] = make([]game.MatchType, len(attempt))
+			for k, lr := range attempt {
+				rows[j][k] = lr.MatchType
+			}
+		}
+		grid.Boards[i] = rows
+	}
+	return grid
+}
+
+// FromGame builds the grid of a single board game.
+func FromGame(g *game.Game) *Grid {
+	return New(g.MaxGuesses, g.HardMode, reveal(g.Attempts, g.Answer))
+}
+
+// FromMultiGame builds the grid of every board in m.
+func FromMultiGame(m *game.MultiGame, maxGuesses int, hardMode bool) *Grid {
+	boards := make([][][]game.LetterResult, len(m.Boards))
+	for i, board := range m.Boards {
+		boards[i] = reveal(board.History(), board.Solution())
+	}
+	return New(maxGuesses, hardMode, boards...)
+}
+
+// reveal returns a copy of history with every lie replaced by the true
+// feedback, so that a solved Fibble board ends in a row of hits.
+func reveal(history [][]game.LetterResult, answer string) [][]game.LetterResult {
+	rows := make([][]game.LetterResult, len(history))
+	for i, row := range history {
+		rows[i] = slices.Clone(row)
+	}
+	for _, lie := range game.Lies(history, answer) {
+		for j := range rows[lie.Attempt] {
+			if rows[lie.Attempt][j].Position == lie.Position {
+				rows[lie.Attempt][j].MatchType = lie.Actual
+			}
+		}
+	}
+	return rows
+}
+
+// Scores returns the number of guesses each board took, or 0 for boards that
+// were not solved.
+func (g *Grid) Scores() []int {
+	scores := make([]int, len(g.Boards))
+	for i, rows := range g.Boards {
+		if len(rows) > 0 && solved(rows[len(rows)-1]) {
+			scores[i] = len(rows)
+		}
+	}
+	return scores
+}
+
+// String renders the grid as share text: a header such as "Wordle #123 4/6*"
+// followed by the rows of each board, with boards separated by a blank line.
+func (g *Grid) String() string {
+	var b strings.Builder
+	title := g.Title
+	if title == "" {
+		title = DefaultTitle
+	}
+	b.WriteString(title)
+	if g.Puzzle > 0 {
+		fmt.Fprintf(&b, " #%d", g.Puzzle)
+	}
+	scores := make([]string, len(g.Boards))
+	for i, score := range g.Scores() {
+		scores[i] = "X"
+		if score > 0 {
+			scores[i] = strconv.Itoa(score)
+		}
+	}
+	fmt.Fprintf(&b, " %s/%d", strings.Join(scores, "&"), g.MaxGuesses)
+	if g.HardMode {
+		b.WriteString("*")
+	}
+	for _, rows := range g.Boards {
+		b.WriteString("\n")
+		for _, row := range rows {
+			b.WriteString("\n")
+			for _, matchType := range row {
+				switch matchType {
+				case game.Hit:
+					b.WriteString(HitTile)
+				case game.Present:
+					b.WriteString(PresentTile)
+				case game.Higher:
+					b.WriteString(HigherTile)
+				case game.Lower:
+					b.WriteString(LowerTile)
+				default:
+					b.WriteString(MissTile)
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// Parse reads share text produced by String or by other Wordle clients. It
+// tolerates the whitespace and variation selectors chat apps add, but
+// rejects grids whose rows do not agree with the scores in the header.
+func Parse(text string) (*Grid, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\ufe0f", "")
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	match := header.FindStringSubmatch(strings.TrimSpace(lines[0]))
+	if match == nil {
+		return nil, fmt.Errorf("invalid header %q", strings.TrimSpace(lines[0]))
+	}
+	grid := &Grid{Title: match[1], HardMode: match[5] == "*"}
+	if match[2] != "" {
+		puzzle, err := strconv.Atoi(strings.ReplaceAll(match[2], ",", ""))
+		if err != nil {
+			return nil, fmt.Errorf("invalid puzzle number %q", match[2])
+		}
+		grid.Puzzle = puzzle
+	}
+	maxGuesses, err := strconv.Atoi(match[4])
+	if err != nil || maxGuesses < 1 {
+		return nil, fmt.Errorf("invalid guess limit %q", match[4])
+	}
+	grid.MaxGuesses = maxGuesses
+	scores := strings.Split(match[3], "&")
+
+	// Boards are separated by blank lines.
+	var rows [][]game.MatchType
+	width := 0
+	for i, line := range append(lines[1:], "") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if rows != nil {
+				grid.Boards = append(grid.Boards, rows)
+				rows = nil
+			}
+			continue
+		}
+		row, err := parseRow(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+2, err)
+		}
+		if width == 0 {
+			width = len(row)
+		}
+		if len(row) != width {
+			return nil, fmt.Errorf("line %d: expected %d tiles, got %d", i+2, width, len(row))
+		}
+		rows = append(rows, row)
+	}
+	if len(grid.Boards) != len(scores) {
+		return nil, fmt.Errorf("header has %d scores but grid has %d boards", len(scores), len(grid.Boards))
+	}
+	if !game.IsValidLength(width) {
+		return nil, fmt.Errorf("rows must have between %d and %d tiles", game.MinWordLength, game.MaxWordLength)
+	}
+	for i, rows := range grid.Boards {
+		if err := checkBoard(rows, scores[i], maxGuesses); err != nil {
+			if len(scores) > 1 {
+				return nil, fmt.Errorf("board %d: %w", i+1, err)
+			}
+			return nil, err
+		}
+	}
+	return grid, nil
+}
+
+func parseRow(line string) ([]game.MatchType, error) {
+	row := make([]game.MatchType, 0, len(line)/4)
+	for _, r := range line {
+		matchType, ok := tiles[r]
+		if !ok {
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+		row = append(row, matchType)
+	}
+	return row, nil
+}
+
+// checkBoard verifies that rows agree with score: a solved board ends on its
+// only all-Hit row, and an unsolved one has no all-Hit row.
+func checkBoard(rows [][]game.MatchType, score string, maxGuesses int) error {
+	if len(rows) > maxGuesses {
+		return fmt.Errorf("%d rows exceed the guess limit of %d", len(rows), maxGuesses)
+	}
+	for i, row := range rows[:len(rows)-1] {
+		if solved(row) {
+			return fmt.Errorf("row %d is solved but is not the last row", i+1)
+		}
+	}
+	last := solved(rows[len(rows)-1])
+	if score == "X" {
+		if last {
+			return errors.New("score is X but the last row is solved")
+		}
+		return nil
+	}
+	n, err := strconv.Atoi(score)
+	if err != nil || n < 1 || n > maxGuesses {
+		return fmt.Errorf("invalid score %q", score)
+	}
+	if n != len(rows) {
+		return fmt.Errorf("score is %d but grid has %d rows", n, len(rows))
+	}
+	if !last {
+		return errors.New("last row is not solved")
+	}
+	return nil
+}
+
+func solved(row []game.MatchType) bool {
+	for _, matchType := range row {
+		if matchType != game.Hit {
+			return false
+		}
+	}
+	return true
+}
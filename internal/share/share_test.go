@@ -0,0 +1,113 @@
+package share
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tomlaws/wordle/internal/game"
+)
+
+func TestFromGame_String(t *testing.T) {
+	g := game.NewGame("apple", 6, game.WithHardMode(true))
+	for _, guess := range []string{"plead", "apple"} {
+		if _, err := g.MakeGuess(guess); err != nil {
+			t.Fatalf("MakeGuess(%q) failed: %v", guess, err)
+		}
+	}
+	grid := FromGame(g)
+	grid.Puzzle = 123
+	want := "Wordle #123 2/6*\n\n🟨🟨🟨🟨⬛\n🟩🟩🟩🟩🟩"
+	if got := grid.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestFromGame_RevealsLies(t *testing.T) {
+	lying := game.NewLyingEvaluator(game.EvaluatorFunc(game.Score), rand.NewSource(1))
+	g := game.NewGame("apple", 6, game.WithEvaluator(lying))
+	for _, guess := range []string{"plead", "apple"} {
+		if _, err := g.MakeGuess(guess); err != nil {
+			t.Fatalf("MakeGuess(%q) failed: %v", guess, err)
+		}
+	}
+	want := "Wordle 2/6\n\n🟨🟨🟨🟨⬛\n🟩🟩🟩🟩🟩"
+	if got := FromGame(g).String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestString_Lost(t *testing.T) {
+	g := game.NewGame("apple", 2)
+	for _, guess := range []string{"mango", "grape"} {
+		if _, err := g.MakeGuess(guess); err != nil {
+			t.Fatalf("MakeGuess(%q) failed: %v", guess, err)
+		}
+	}
+	if got := FromGame(g).String(); !strings.HasPrefix(got, "Wordle X/2\n") {
+		t.Errorf("unexpected header in %q", got)
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	m := game.NewMultiGame(game.NewGame("apple", 6), game.NewGame("grape", 6))
+	for _, guess := range []string{"apple", "mango", "grape"} {
+		if _, err := m.MakeGuess(guess); err != nil {
+			t.Fatalf("MakeGuess(%q) failed: %v", guess, err)
+		}
+	}
+	grid := FromMultiGame(m, 6, false)
+	parsed, err := Parse(grid.String())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	grid.Title = DefaultTitle
+	if !reflect.DeepEqual(parsed, grid) {
+		t.Errorf("Parse(String()) = %+v, want %+v", parsed, grid)
+	}
+	if got := parsed.Scores(); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Scores() = %v, want [1 3]", got)
+	}
+}
+
+func TestParse_PastedFromChat(t *testing.T) {
+	text := "  Wordle 1,234 3/6*\r\n\r\n⬜🟨⬜⬜⬜ \r\n⬜⬜🟩️🟩⬜\r\n🟩🟩🟩🟩🟩\r\n"
+	grid, err := Parse(text)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if grid.Puzzle != 1234 || grid.MaxGuesses != 6 || !grid.HardMode {
+		t.Errorf("unexpected header fields %+v", grid)
+	}
+	want := [][]game.MatchType{
+		{game.Miss, game.Present, game.Miss, game.Miss, game.Miss},
+		{game.Miss, game.Miss, game.Hit, game.Hit, game.Miss},
+		{game.Hit, game.Hit, game.Hit, game.Hit, game.Hit},
+	}
+	if !reflect.DeepEqual(grid.Boards, [][][]game.MatchType{want}) {
+		t.Errorf("Boards = %v, want %v", grid.Boards, want)
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	tests := map[string]string{
+		"no grid":          "Wordle 1/6",
+		"bad header":       "Wordle 1 of 6\n\n🟩🟩🟩🟩🟩",
+		"score mismatch":   "Wordle 2/6\n\n🟩🟩🟩🟩🟩",
+		"unsolved last":    "Wordle 1/6\n\n🟩🟩🟩🟩⬛",
+		"solved but X":     "Wordle X/6\n\n🟩🟩🟩🟩🟩",
+		"early solve":      "Wordle X/6\n\n🟩🟩🟩🟩🟩\n⬛⬛⬛⬛⬛",
+		"ragged rows":      "Wordle 2/6\n\n⬛⬛⬛⬛\n🟩🟩🟩🟩🟩",
+		"too many rows":    "Wordle X/1\n\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛",
+		"stray text":       "Wordle 1/6\n\n🟩🟩🟩🟩🟩 nice",
+		"missing board":    "Wordle 1&1/6\n\n🟩🟩🟩🟩🟩",
+		"too short":        "Wordle 1/6\n\n🟩🟩🟩",
+		"score over limit": "Wordle 7/6\n\n🟩🟩🟩🟩🟩",
+	}
+	for name, text := range tests {
+		if _, err := Parse(text); err == nil {
+			t.Errorf("%s: expected %q to be rejected", name, text)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package share
+
+import (
+	"github.com/tomlaws/wordle/internal/game"
+)
+
+// Grid is a finished game reduced to what a share grid shows: the colour of
+// every tile, but not the letters.
+type Grid struct {
+	// Title names the game in the header, "Wordle" by default.
+	Title string
+	// Puzzle is the daily puzzle number, or 0 if the game was not a daily.
+	Puzzle     int
+	MaxGuesses int
+	HardMode   bool
+	// Boards holds the rows of each board in the order they were played.
+	Boards [][][]game.MatchType
+}
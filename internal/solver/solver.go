@@ -0,0 +1,168 @@
+package solver
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tomlaws/wordle/internal/game"
+)
+
+// New creates a solver for answers of the given length drawn from provider.
+// Every guess the provider lists is considered when ranking.
+func New(provider game.AnswerProvider, length int, opts ...Option) *Solver {
+	answers := provider.Answers(length)
+	s := &Solver{
+		answers:    answers,
+		guesses:    provider.Guesses(length),
+		candidates: answers,
+		length:     length,
+		strategy:   Entropy,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// WithStrategy sets the ranking used by Suggest.
+func WithStrategy(strategy Strategy) Option {
+	return func(s *Solver) {
+		s.strategy = strategy
+	}
+}
+
+// WithHardMode restricts suggestions to words that may still be the answer,
+// which always satisfies the hard mode rules.
+func WithHardMode(enabled bool) Option {
+	return func(s *Solver) {
+		s.hardMode = enabled
+	}
+}
+
+// WithLies expects exactly one tile of every feedback row to be false, as in
+// Fibble.
+func WithLies(enabled bool) Option {
+	return func(s *Solver) {
+		s.lies = enabled
+	}
+}
+
+// Update narrows the candidates to the answers consistent with every row of
+// history. With lies enabled, an answer is consistent if every row differs
+// from its true feedback in exactly one tile.
+func (s *Solver) Update(history [][]game.LetterResult) {
+	knowledge := game.NewKnowledge(s.length, history)
+	candidates := make([]string, 0, len(s.answers))
+	for _, answer := range s.answers {
+		if s.lies && s.oneLiePerRow(history, answer) || !s.lies && knowledge.IsConsistent(answer) {
+			candidates = append(candidates, answer)
+		}
+	}
+	s.candidates = candidates
+}
+
+func (s *Solver) oneLiePerRow(history [][]game.LetterResult, answer string) bool {
+	for _, row := range history {
+		if len(row) != s.length || game.CountLies(row, answer) != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Candidates returns the answers that are still possible.
+func (s *Solver) Candidates() []string {
+	return append([]string(nil), s.candidates...)
+}
+
+// Suggest returns up to n guesses ranked by the solver's strategy.
+func (s *Solver) Suggest(n int) []Suggestion {
+	if len(s.candidates) == 0 || n <= 0 {
+		return nil
+	}
+	possible := make(map[string]bool, len(s.candidates))
+	for _, c := range s.candidates {
+		possible[c] = true
+	}
+	pool := s.guesses
+	if s.hardMode || len(s.candidates) <= 2 {
+		pool = s.candidates
+	}
+	suggestions := make([]Suggestion, 0, len(pool))
+	for _, guess := range pool {
+		suggestions = append(suggestions, s.evaluate(guess, possible[guess]))
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		return s.less(suggestions[i], suggestions[j])
+	})
+	if n > len(suggestions) {
+		n = len(suggestions)
+	}
+	return suggestions[:n]
+}
+
+// evaluate scores guess by partitioning the candidates into buckets that
+// share the same feedback pattern.
+func (s *Solver) evaluate(guess string, possible bool) Suggestion {
+	buckets := make(map[int]int)
+	for _, candidate := range s.candidates {
+		buckets[game.Pattern(game.Score(guess, candidate))]++
+	}
+	total := float64(len(s.candidates))
+	var entropy float64
+	worst := 0
+	for _, size := range buckets {
+		p := float64(size) / total
+		entropy -= p * math.Log2(p)
+		if size > worst {
+			worst = size
+		}
+	}
+	return Suggestion{Word: guess, Entropy: entropy, Worst: worst, Possible: possible}
+}
+
+func (s *Solver) less(a, b Suggestion) bool {
+	const epsilon = 1e-9
+	switch s.strategy {
+	case Minimax:
+		if a.Worst != b.Worst {
+			return a.Worst < b.Worst
+		}
+		if math.Abs(a.Entropy-b.Entropy) > epsilon {
+			return a.Entropy > b.Entropy
+		}
+	default:
+		if math.Abs(a.Entropy-b.Entropy) > epsilon {
+			return a.Entropy > b.Entropy
+		}
+		if a.Worst != b.Worst {
+			return a.Worst < b.Worst
+		}
+	}
+	if a.Possible != b.Possible {
+		return a.Possible
+	}
+	return a.Word < b.Word
+}
+
+// Rate returns how many guesses a solver created with opts needs to find
+// answer, following its top suggestion every turn. It can be stored as the
+// difficulty column of a word list. It returns 0 if answer is never found.
+func Rate(provider game.AnswerProvider, answer string, opts ...Option) int {
+	s := New(provider, game.Length(answer), opts...)
+	g := game.NewGame(answer, len(s.answers))
+	for g.Status() == game.InProgress {
+		suggestions := s.Suggest(1)
+		if len(suggestions) == 0 {
+			return 0
+		}
+		if _, err := g.MakeGuess(suggestions[0].Word); err != nil {
+			return 0
+		}
+		s.Update(g.Attempts)
+	}
+	if g.Status() != game.Won {
+		return 0
+	}
+	return len(g.Attempts)
+}
@@ -0,0 +1,134 @@
+package solver
+
+import (
+	"math/rand"
+	"path"
+	"testing"
+
+	"github.com/tomlaws/wordle/internal/game"
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+func loadWordList(t *testing.T) *game.WordList {
+	wordList, err := game.NewWordList(
+		path.Join(utils.Root, "assets", "words.txt"),
+		game.WithAllowedGuesses(path.Join(utils.Root, "assets", "allowed.txt")),
+	)
+	if err != nil {
+		t.Fatalf("Failed to load word list: %v", err)
+	}
+	return wordList
+}
+
+func TestSolver_UpdateFiltersCandidates(t *testing.T) {
+	wordList := loadWordList(t)
+	s := New(wordList, 5)
+	g := game.NewGame("smile", 6)
+	g.MakeGuess("skill")
+	s.Update(g.Attempts)
+	candidates := s.Candidates()
+	if len(candidates) == 0 {
+		t.Fatalf("Expected the answer to remain a candidate")
+	}
+	for _, c := range candidates {
+		if game.Pattern(game.Score("skill", c)) != game.Pattern(g.Attempts[0]) {
+			t.Errorf("Candidate %s is inconsistent with the feedback", c)
+		}
+	}
+	found := false
+	for _, c := range candidates {
+		if c == "smile" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected smile to remain a candidate, got %v", candidates)
+	}
+}
+
+func TestSolver_SuggestRanksByEntropy(t *testing.T) {
+	wordList := loadWordList(t)
+	s := New(wordList, 5)
+	suggestions := s.Suggest(5)
+	if len(suggestions) != 5 {
+		t.Fatalf("Expected 5 suggestions, got %d", len(suggestions))
+	}
+	for i := 1; i < len(suggestions); i++ {
+		if suggestions[i].Entropy > suggestions[i-1].Entropy+1e-9 {
+			t.Errorf("Suggestions not ordered by entropy: %v", suggestions)
+		}
+	}
+}
+
+func TestSolver_SuggestMinimax(t *testing.T) {
+	wordList := loadWordList(t)
+	s := New(wordList, 5, WithStrategy(Minimax))
+	suggestions := s.Suggest(5)
+	for i := 1; i < len(suggestions); i++ {
+		if suggestions[i].Worst < suggestions[i-1].Worst {
+			t.Errorf("Suggestions not ordered by worst case: %v", suggestions)
+		}
+	}
+}
+
+func TestSolver_SolvesEveryAnswer(t *testing.T) {
+	wordList := loadWordList(t)
+	for _, answer := range wordList.Answers(5) {
+		g := game.NewGame(answer, 6)
+		s := New(wordList, 5)
+		for g.State == game.InProgress {
+			suggestions := s.Suggest(1)
+			if len(suggestions) == 0 {
+				t.Fatalf("No suggestion for answer %s after %d guesses", answer, len(g.Attempts))
+			}
+			g.MakeGuess(suggestions[0].Word)
+			s.Update(g.Attempts)
+		}
+		if g.State != game.Won {
+			t.Errorf("Solver failed to find %s within 6 guesses", answer)
+		}
+	}
+}
+
+func TestSolver_WithLiesAllowsOneLiePerRow(t *testing.T) {
+	wordList := loadWordList(t)
+	evaluator := game.NewLyingEvaluator(game.EvaluatorFunc(game.Score), rand.NewSource(7))
+	g := game.NewGame("smile", 6, game.WithEvaluator(evaluator))
+	for _, guess := range []string{"crane", "skill", "house"} {
+		if _, err := g.MakeGuess(guess); err != nil {
+			t.Fatalf("MakeGuess(%q) failed: %v", guess, err)
+		}
+	}
+	s := New(wordList, 5, WithLies(true))
+	s.Update(g.Attempts)
+	found := false
+	for _, c := range s.Candidates() {
+		if c == "smile" {
+			found = true
+		}
+		for i, row := range g.Attempts {
+			if lies := game.CountLies(row, c); lies != 1 {
+				t.Errorf("Candidate %s has %d lies in row %d", c, lies, i+1)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected the answer to remain a candidate, got %v", s.Candidates())
+	}
+	if len(s.Suggest(1)) == 0 {
+		t.Error("Expected a suggestion")
+	}
+}
+
+func TestRate(t *testing.T) {
+	wordList := loadWordList(t)
+	for _, answer := range []string{"apple", "scale", "spoon"} {
+		guesses := Rate(wordList, answer, WithHardMode(true))
+		if guesses < 1 || guesses > len(wordList.Answers(5)) {
+			t.Errorf("Expected %s to be solved, took %d guesses", answer, guesses)
+		}
+	}
+	if guesses := Rate(wordList, "zzzzz", WithHardMode(true)); guesses != 0 {
+		t.Errorf("Expected a word outside the answers to be unsolvable, took %d guesses", guesses)
+	}
+}
@@ -0,0 +1,39 @@
+package solver
+
+// Strategy decides how Suggest orders candidate guesses.
+type Strategy int
+
+const (
+	// Entropy prefers guesses with the highest expected information gain.
+	Entropy Strategy = iota
+	// Minimax prefers guesses whose worst-case feedback leaves the fewest
+	// candidates.
+	Minimax
+)
+
+// Suggestion is a ranked guess together with the scores used to rank it.
+type Suggestion struct {
+	Word string `json:"word"`
+	// Entropy is the expected information gain of the guess in bits.
+	Entropy float64 `json:"entropy"`
+	// Worst is the number of candidates left by the least helpful feedback.
+	Worst int `json:"worst"`
+	// Possible reports whether the guess may itself be the answer.
+	Possible bool `json:"possible"`
+}
+
+// Solver tracks the answers that remain possible for a single board and
+// ranks the next guesses over them.
+type Solver struct {
+	answers    []string
+	guesses    []string
+	candidates []string
+	length     int
+	strategy   Strategy
+	hardMode   bool
+	// lies allows exactly one falsified tile in every feedback row.
+	lies bool
+}
+
+// Option configures a Solver created by New.
+type Option func(*Solver)
@@ -0,0 +1,55 @@
+package wordlist
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+// Build merges lists into one list sorted by word, lowercasing every word
+// and dropping duplicates and the words in blocklist. The first entry of a
+// word keeps its weight and difficulty.
+func Build(lists [][]utils.WordEntry, blocklist []string) []utils.WordEntry {
+	skip := make(map[string]bool)
+	for _, word := range blocklist {
+		skip[strings.ToLower(word)] = true
+	}
+	var entries []utils.WordEntry
+	for _, list := range lists {
+		for _, entry := range list {
+			entry.Word = strings.ToLower(entry.Word)
+			if skip[entry.Word] {
+				continue
+			}
+			skip[entry.Word] = true
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Word < entries[j].Word
+	})
+	return entries
+}
+
+// Write writes entries one per line, leaving out a weight of 1 unless a
+// difficulty follows it.
+func Write(w io.Writer, entries []utils.WordEntry) error {
+	buffered := bufio.NewWriter(w)
+	for _, entry := range entries {
+		line := entry.Word
+		if entry.Weight != 1 || entry.Difficulty > 0 {
+			line += " " + strconv.FormatFloat(entry.Weight, 'g', -1, 64)
+		}
+		if entry.Difficulty > 0 {
+			line += " " + strconv.FormatFloat(entry.Difficulty, 'g', -1, 64)
+		}
+		if _, err := buffered.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+	return buffered.Flush()
+}
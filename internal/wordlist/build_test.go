@@ -0,0 +1,24 @@
+package wordlist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+func TestBuild(t *testing.T) {
+	lists := [][]utils.WordEntry{
+		{{Word: "Spoon", Weight: 1}, {Word: "apple", Weight: 3, Difficulty: 4}},
+		{{Word: "apple", Weight: 1}, {Word: "crane", Weight: 1}, {Word: "kitty", Weight: 2}},
+	}
+	entries := Build(lists, []string{"KITTY"})
+	var output strings.Builder
+	if err := Write(&output, entries); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	expected := "apple 3 4\ncrane\nspoon\n"
+	if output.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, output.String())
+	}
+}
@@ -0,0 +1,116 @@
+// Package wordlist checks and curates word list files.
+package wordlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tomlaws/wordle/internal/game"
+	"github.com/tomlaws/wordle/pkg/utils"
+)
+
+// AnyLength makes Lint accept words of every supported length, for lists
+// that mix lengths on purpose.
+const AnyLength = -1
+
+// Lint reports the lines of the word list in r that the loader would accept
+// but that are likely mistakes: duplicates, uppercase, non-letter runes,
+// stray whitespace and unsupported lengths. Words that do not have length
+// letters are reported too; if length is zero, that is the most common
+// length in the list. The list may be gzip-compressed.
+func Lint(r io.Reader, length int) ([]Problem, error) {
+	r, err := utils.Decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	var problems []Problem
+	seen := make(map[string]int)
+	// lengths holds the line and word of every word by its length, to be
+	// checked against the most common length once the whole list is read.
+	lengths := make(map[int][]Problem)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		trimmed := strings.TrimSpace(text)
+		report := func(word, format string, args ...any) {
+			problems = append(problems, Problem{Line: line, Word: word, Message: fmt.Sprintf(format, args...)})
+		}
+		if trimmed == "" {
+			if text != "" {
+				report("", "blank line with whitespace")
+			}
+			continue
+		}
+		entry, err := utils.ParseWordEntry(text)
+		if err != nil {
+			report("", "%v", err)
+			continue
+		}
+		word := entry.Word
+		if trimmed != text {
+			report(word, "leading or trailing whitespace")
+		}
+		if strings.Join(strings.Fields(trimmed), " ") != trimmed {
+			report(word, "columns should be separated by a single space")
+		}
+		if strings.ToLower(word) != word {
+			report(word, "contains uppercase letters")
+		}
+		for _, c := range word {
+			if !unicode.IsLetter(c) {
+				report(word, "contains non-letter %q", c)
+				break
+			}
+		}
+		letters := utf8.RuneCountInString(word)
+		if length > 0 && letters != length {
+			report(word, "has %d letters, expected %d", letters, length)
+		} else if length <= 0 && !game.IsValidLength(letters) {
+			report(word, "has %d letters, must be between %d and %d", letters, game.MinWordLength, game.MaxWordLength)
+		} else if length == 0 {
+			lengths[letters] = append(lengths[letters], Problem{Line: line, Word: word})
+		}
+		key := strings.ToLower(word)
+		if first, exists := seen[key]; exists {
+			report(word, "duplicate of line %d", first)
+		} else {
+			seen[key] = line
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	common := 0
+	for letters, words := range lengths {
+		if n := len(lengths[common]); len(words) > n || len(words) == n && letters < common {
+			common = letters
+		}
+	}
+	for letters, words := range lengths {
+		if letters == common {
+			continue
+		}
+		for _, problem := range words {
+			problem.Message = fmt.Sprintf("has %d letters, most words have %d", letters, common)
+			problems = append(problems, problem)
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line < problems[j].Line
+	})
+	return problems, nil
+}
+
+func (p Problem) String() string {
+	if p.Word == "" {
+		return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", p.Line, p.Word, p.Message)
+}
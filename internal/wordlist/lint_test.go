@@ -0,0 +1,68 @@
+package wordlist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	content := strings.Join([]string{
+		"apple",
+		"Beach",
+		" bread",
+		"chair  2",
+		"don't",
+		"apple",
+		"   ",
+		"",
+		"eagle 0",
+		"ant",
+		"dance 2 4",
+	}, "\n")
+	problems, err := Lint(strings.NewReader(content), 0)
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	expected := []string{
+		"line 2: Beach: contains uppercase letters",
+		"line 3: bread: leading or trailing whitespace",
+		"line 4: chair: columns should be separated by a single space",
+		"line 5: don't: contains non-letter '\\''",
+		"line 6: apple: duplicate of line 1",
+		"line 7: blank line with whitespace",
+		"line 9: invalid weight \"0\"",
+		"line 10: ant: has 3 letters, must be between 4 and 11",
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("Expected %d problems, got %v", len(expected), problems)
+	}
+	for i, problem := range problems {
+		if problem.String() != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], problem.String())
+		}
+	}
+}
+
+func TestLint_Length(t *testing.T) {
+	problems, err := Lint(strings.NewReader("apple\nbank\n"), 5)
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if len(problems) != 1 || problems[0].String() != "line 2: bank: has 4 letters, expected 5" {
+		t.Errorf("Expected the word of another length to be reported, got %v", problems)
+	}
+}
+
+func TestLint_MixedLengths(t *testing.T) {
+	content := "apple\nbeach\nbread\ncarrot\n"
+	problems, err := Lint(strings.NewReader(content), 0)
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if len(problems) != 1 || problems[0].String() != "line 4: carrot: has 6 letters, most words have 5" {
+		t.Errorf("Expected the word of the uncommon length to be reported, got %v", problems)
+	}
+	if problems, err := Lint(strings.NewReader(content), AnyLength); err != nil || len(problems) != 0 {
+		t.Errorf("Expected mixed lengths to be accepted, got %v %v", problems, err)
+	}
+}
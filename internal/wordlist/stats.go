@@ -0,0 +1,30 @@
+package wordlist
+
+import "unicode/utf8"
+
+// Summarize counts the words of each length and how often each letter
+// appears, overall and at each position.
+func Summarize(words []string) Stats {
+	stats := Stats{
+		Words:    len(words),
+		ByLength: make(map[int]int),
+		Letters:  make(map[rune]int),
+	}
+	for _, word := range words {
+		stats.ByLength[utf8.RuneCountInString(word)]++
+		seen := make(map[rune]bool)
+		position := 0
+		for _, r := range word {
+			if position == len(stats.Positions) {
+				stats.Positions = append(stats.Positions, make(map[rune]int))
+			}
+			stats.Positions[position][r]++
+			position++
+			if !seen[r] {
+				seen[r] = true
+				stats.Letters[r]++
+			}
+		}
+	}
+	return stats
+}
@@ -0,0 +1,16 @@
+package wordlist
+
+import "testing"
+
+func TestSummarize(t *testing.T) {
+	stats := Summarize([]string{"apple", "angel", "bank"})
+	if stats.Words != 3 || stats.ByLength[5] != 2 || stats.ByLength[4] != 1 {
+		t.Errorf("Expected lengths to be counted, got %v", stats.ByLength)
+	}
+	if stats.Letters['p'] != 1 || stats.Letters['a'] != 3 {
+		t.Errorf("Expected letters to be counted once per word, got %v", stats.Letters)
+	}
+	if stats.Positions[0]['a'] != 2 || stats.Positions[4]['e'] != 1 || stats.Positions[4]['l'] != 1 {
+		t.Errorf("Expected letters to be counted by position, got %v", stats.Positions)
+	}
+}
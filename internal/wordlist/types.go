@@ -0,0 +1,19 @@
+package wordlist
+
+// Problem is an issue Lint found on a line of a word list.
+type Problem struct {
+	Line    int
+	Word    string
+	Message string
+}
+
+// Stats summarizes the words of a list.
+type Stats struct {
+	Words int
+	// ByLength counts the words of each length in letters.
+	ByLength map[int]int
+	// Letters counts the words containing each letter at least once.
+	Letters map[rune]int
+	// Positions counts the words with each letter at each position.
+	Positions []map[rune]int
+}
@@ -2,10 +2,11 @@ package utils
 
 import (
 	"math/rand"
-	"time"
 )
 
+// RandomInt returns a random number in [min, max] from the global source,
+// which is seeded automatically. Callers that need reproducible numbers
+// should use their own rand.Rand instead.
 func RandomInt(min, max int) int {
-	rand.Seed(time.Now().UnixNano())
 	return rand.Intn(max-min+1) + min
 }
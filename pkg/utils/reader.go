@@ -2,27 +2,128 @@ package utils
 
 import (
 	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
 	"os"
+	"strconv"
 	"strings"
 )
 
+// WordEntry is a line of a word list.
+type WordEntry struct {
+	Word string
+	// Weight is how likely the word is to be picked, 1 unless given.
+	Weight float64
+	// Difficulty is a rating such as the guesses a solver needs, or 0 if the
+	// line has none.
+	Difficulty float64
+}
+
+// LoadWords reads one word per line, ignoring blank lines and any weight or
+// difficulty column.
 func LoadWords(filename string) ([]string, error) {
+	words, _, err := LoadWeightedWords(filename)
+	return words, err
+}
+
+// LoadWeightedWords reads one word per line, optionally followed by
+// whitespace and a positive weight such as a frequency count. Words without a
+// weight get a weight of 1.
+func LoadWeightedWords(filename string) ([]string, []float64, error) {
+	entries, err := LoadWordEntries(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	words := make([]string, len(entries))
+	weights := make([]float64, len(entries))
+	for i, entry := range entries {
+		words[i] = entry.Word
+		weights[i] = entry.Weight
+	}
+	return words, weights, nil
+}
+
+// LoadWordEntries reads one word per line, optionally followed by a positive
+// weight and then a positive difficulty rating, separated by whitespace. The
+// file may be gzip-compressed.
+func LoadWordEntries(filename string) ([]WordEntry, error) {
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
+	return ReadWordEntries(file, filename)
+}
 
-	var words []string
-	scanner := bufio.NewScanner(file)
+// ReadWordEntries is like LoadWordEntries but reads from r. Errors name the
+// offending line of filename.
+func ReadWordEntries(r io.Reader, filename string) ([]WordEntry, error) {
+	r, err := Decompress(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	var entries []WordEntry
+	scanner := bufio.NewScanner(r)
+	line := 0
 	for scanner.Scan() {
-		word := strings.TrimSpace(scanner.Text())
-		if word != "" {
-			words = append(words, word)
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		entry, err := ParseWordEntry(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filename, line, err)
 		}
+		entries = append(entries, entry)
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
-	return words, nil
+	return entries, nil
+}
+
+// Decompress returns a reader of the decompressed contents of r if it holds
+// gzip data, or a reader of r as is otherwise.
+func Decompress(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	if magic, _ := buffered.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(buffered)
+	}
+	return buffered, nil
+}
+
+// ParseWordEntry parses a non-blank line of a word list.
+func ParseWordEntry(line string) (WordEntry, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || len(fields) > 3 {
+		return WordEntry{}, errors.New("expected a word, an optional weight and an optional difficulty")
+	}
+	entry := WordEntry{Word: fields[0], Weight: 1}
+	var err error
+	if len(fields) > 1 {
+		if entry.Weight, err = parsePositive(fields[1]); err != nil {
+			return WordEntry{}, fmt.Errorf("invalid weight %q", fields[1])
+		}
+	}
+	if len(fields) > 2 {
+		if entry.Difficulty, err = parsePositive(fields[2]); err != nil {
+			return WordEntry{}, fmt.Errorf("invalid difficulty %q", fields[2])
+		}
+	}
+	return entry, nil
+}
+
+// parsePositive parses a finite number greater than zero.
+func parsePositive(field string) (float64, error) {
+	value, err := strconv.ParseFloat(field, 64)
+	if err != nil {
+		return 0, err
+	}
+	if value <= 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0, fmt.Errorf("%v is not positive", value)
+	}
+	return value, nil
 }
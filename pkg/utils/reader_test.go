@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"compress/gzip"
 	"os"
 	"testing"
 )
@@ -38,3 +39,96 @@ func TestLoadWords(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadWeightedWords(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "weighted_test_*.txt")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	content := "apple 120\nbanana\n\ncarrot\t0.5\n"
+	if _, err := tmpFile.WriteString(content); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tmpFile.Close()
+
+	words, weights, err := LoadWeightedWords(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("LoadWeightedWords failed: %v", err)
+	}
+	expectedWords := []string{"apple", "banana", "carrot"}
+	expectedWeights := []float64{120, 1, 0.5}
+	if len(words) != len(expectedWords) || len(weights) != len(expectedWeights) {
+		t.Fatalf("Expected %d words and weights, got %v and %v", len(expectedWords), words, weights)
+	}
+	for i := range expectedWords {
+		if words[i] != expectedWords[i] || weights[i] != expectedWeights[i] {
+			t.Errorf("Expected %s with weight %v at index %d, got %s with weight %v", expectedWords[i], expectedWeights[i], i, words[i], weights[i])
+		}
+	}
+
+	for _, content := range []string{"apple zero\n", "apple 0\n", "apple -1\n", "apple 1 0\n", "apple 1 2 3\n"} {
+		if err := os.WriteFile(tmpFile.Name(), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write to temp file: %v", err)
+		}
+		if _, _, err := LoadWeightedWords(tmpFile.Name()); err == nil {
+			t.Errorf("Expected %q to be rejected", content)
+		}
+	}
+}
+
+func TestLoadWordEntries(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "entries_test_*.txt")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	content := "apple 120 3.5\nbanana\ncarrot 2\n"
+	if _, err := tmpFile.WriteString(content); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tmpFile.Close()
+
+	entries, err := LoadWordEntries(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("LoadWordEntries failed: %v", err)
+	}
+	expected := []WordEntry{
+		{Word: "apple", Weight: 120, Difficulty: 3.5},
+		{Word: "banana", Weight: 1},
+		{Word: "carrot", Weight: 2},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %v", len(expected), entries)
+	}
+	for i := range expected {
+		if entries[i] != expected[i] {
+			t.Errorf("Expected %v at index %d, got %v", expected[i], i, entries[i])
+		}
+	}
+}
+
+func TestLoadWordEntries_Gzip(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "entries_test_*.txt.gz")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	compressed := gzip.NewWriter(tmpFile)
+	if _, err := compressed.Write([]byte("apple 2\nbanana\n")); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	compressed.Close()
+	tmpFile.Close()
+
+	words, err := LoadWords(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("LoadWords failed: %v", err)
+	}
+	if len(words) != 2 || words[0] != "apple" || words[1] != "banana" {
+		t.Errorf("Expected the compressed words, got %v", words)
+	}
+}
@@ -1,22 +1,93 @@
-export class PlayerInfoPayload {
-    id!: string;
-    nickname!: string;
-    
+// Code generated by payloadgen. DO NOT EDIT.
+
+import type { Payload } from '$lib/utils/message';
+
+export type ErrorCode = 'unknown_type' | 'bad_payload' | 'out_of_turn' | 'rate_limited';
+
+export interface Features {
+    variants: string[];
+    encodings: string[];
+    compression: string[];
+    wordLengths?: number[];
+    boards?: number;
+}
+
+export type Feedback = 'wordle' | 'mastermind' | 'peaks' | 'lying';
+
+export interface LetterResult {
+    letter: number;
+    position: number;
+    matchType: number;
+    lie?: boolean;
+}
+
+export interface Lie {
+    board: number;
+    attempt: number;
+    position: number;
+    letter: number;
+    shown: number;
+    actual: number;
+}
+
+export type MessageType = 'error' | 'feedback' | 'game_over' | 'game_start' | 'guess' | 'guess_timeout' | 'hello' | 'invalid_word' | 'matching' | 'play_again' | 'player_info' | 'round_start' | 'typing' | 'welcome';
+
+export type Mode = 'classic' | 'absurd' | 'daily' | 'fibble' | 'nerdle';
+
+export interface Player {
+    id: string;
+    nickname: string;
+}
+
+export type Tier = 'any' | 'easy' | 'normal' | 'hard';
+
+export class ErrorPayload {
+    code!: ErrorCode;
+    rejected?: MessageType;
+    message!: string;
+
     MessageType(): string {
-        return 'player_info';
+        return 'error';
     }
 }
 
-export class MatchingPayload{
+export class FeedbackPayload {
+    player!: Player;
+    round!: number;
+    board!: number;
+    word!: string;
+    feedback!: LetterResult[];
+    keyboard?: Record<string, number>;
+
     MessageType(): string {
-        return 'matching';
+        return 'feedback';
+    }
+}
+
+export class GameOverPayload {
+    winner!: Player | null;
+    answer!: string;
+    answers?: string[];
+    lies?: Lie[];
+    share?: string;
+
+    MessageType(): string {
+        return 'game_over';
     }
 }
 
 export class GameStartPayload {
     maxGuesses!: number;
-    player1!: { id: string; nickname: string; };
-    player2!: { id: string; nickname: string; };
+    wordLength!: number;
+    hardMode!: boolean;
+    mode!: Mode;
+    feedback!: Feedback;
+    tier?: Tier;
+    language!: string;
+    boards!: number;
+    puzzle?: number;
+    player1!: Player;
+    player2!: Player;
 
     MessageType(): string {
         return 'game_start';
@@ -31,64 +102,70 @@ export class GuessPayload {
     }
 }
 
-export class RoundStartPayload {
-    player!: { id: string; nickname: string; };
+export class GuessTimeoutPayload {
+    player!: Player;
     round!: number;
-    deadline!: string;
 
-    getDeadline(): Date {
-        return new Date(this.deadline);
+    MessageType(): string {
+        return 'guess_timeout';
     }
+}
+
+export class HelloPayload {
+    version!: number;
+    features!: Features;
 
     MessageType(): string {
-        return 'round_start';
+        return 'hello';
     }
 }
 
 export class InvalidWordPayload {
-    player!: { id: string; nickname: string; };
+    player!: Player;
     round!: number;
     word!: string;
+    reason?: string;
 
     MessageType(): string {
         return 'invalid_word';
     }
 }
 
-export class GuessTimeoutPayload {
-    player!: { id: string; nickname: string; };
-    round!: number;
+export class MatchingPayload {
+    MessageType(): string {
+        return 'matching';
+    }
+}
+
+export class PlayAgainPayload {
+    confirm!: boolean;
 
     MessageType(): string {
-        return 'guess_timeout';
+        return 'play_again';
     }
 }
 
-export class FeedbackPayload {
-    player!: { id: string; nickname: string; };
-    round!: number;
-    feedback!: Array<{
-        letter: number;
-        position: number;
-        matchType: number;
-    }>;
+export class PlayerInfoPayload {
+    id!: string;
+    nickname!: string;
 
     MessageType(): string {
-        return 'feedback';
+        return 'player_info';
     }
 }
 
-export class GameOverPayload {
-    winner!: { id: string; nickname: string; } | null;
-    answer!: string;
+export class RoundStartPayload {
+    player!: Player;
+    round!: number;
+    deadline!: string;
 
     MessageType(): string {
-        return 'game_over';
+        return 'round_start';
     }
 }
 
 export class TypingPayload {
-    player?: { id: string; nickname: string; };
+    player!: Player;
     word!: string;
 
     MessageType(): string {
@@ -96,10 +173,46 @@ export class TypingPayload {
     }
 }
 
-export class PlayAgainPayload {
-    confirm!: boolean;
+export class WelcomePayload {
+    version!: number;
+    variants!: string[];
+    encoding!: string;
+    compression!: string;
 
     MessageType(): string {
-        return 'play_again';
+        return 'welcome';
     }
-}
\ No newline at end of file
+}
+
+export type Message =
+    | { type: 'error'; payload: ErrorPayload }
+    | { type: 'feedback'; payload: FeedbackPayload }
+    | { type: 'game_over'; payload: GameOverPayload }
+    | { type: 'game_start'; payload: GameStartPayload }
+    | { type: 'guess'; payload: GuessPayload }
+    | { type: 'guess_timeout'; payload: GuessTimeoutPayload }
+    | { type: 'hello'; payload: HelloPayload }
+    | { type: 'invalid_word'; payload: InvalidWordPayload }
+    | { type: 'matching'; payload: MatchingPayload }
+    | { type: 'play_again'; payload: PlayAgainPayload }
+    | { type: 'player_info'; payload: PlayerInfoPayload }
+    | { type: 'round_start'; payload: RoundStartPayload }
+    | { type: 'typing'; payload: TypingPayload }
+    | { type: 'welcome'; payload: WelcomePayload };
+
+export const payloadRegistry = new Map<string, () => Payload>([
+    ['error', () => new ErrorPayload()],
+    ['feedback', () => new FeedbackPayload()],
+    ['game_over', () => new GameOverPayload()],
+    ['game_start', () => new GameStartPayload()],
+    ['guess', () => new GuessPayload()],
+    ['guess_timeout', () => new GuessTimeoutPayload()],
+    ['hello', () => new HelloPayload()],
+    ['invalid_word', () => new InvalidWordPayload()],
+    ['matching', () => new MatchingPayload()],
+    ['play_again', () => new PlayAgainPayload()],
+    ['player_info', () => new PlayerInfoPayload()],
+    ['round_start', () => new RoundStartPayload()],
+    ['typing', () => new TypingPayload()],
+    ['welcome', () => new WelcomePayload()],
+]);
@@ -9,9 +9,13 @@ export type WebSocketConnection<T> = {
 export function createWebSocket<T>(
   url: string,
   wrap: (msg: T) => any,
-  unwrap: (msg: any) => T
+  unwrap: (msg: any) => T,
+  onClose?: (event: CloseEvent) => void
 ): WebSocketConnection<T> {
-  const socket$ = webSocket<any>(url);
+  const socket$ = webSocket<any>({
+    url,
+    closeObserver: { next: event => onClose?.(event) }
+  });
 
   // Wrap sending
   const send = (msg: T) => {